@@ -24,3 +24,55 @@ func AllocN(n int) (st, end int, err error) {
 	end = st + pt
 	return
 }
+
+// AllocNBalanced allocates n items to current mpi proc based on WorldSize and WorldRank,
+// like AllocN, except that n does not need to be an even multiple of the number
+// of procs: any remainder is allocated one each to the lowest ranks,
+// so that no items are dropped.  Returns start and end (exclusive) range
+// for current proc.
+func AllocNBalanced(n int) (st, end int) {
+	return balancedRange(n, mpi.WorldSize(), mpi.WorldRank())
+}
+
+// AllocAllN returns the [start, end) (exclusive) ranges of n items allocated
+// to each of the WorldSize procs, in rank order, using the same balanced
+// distribution as AllocNBalanced, so that a coordinating proc can see the
+// full partition, e.g., for building Scatterv counts or logging the layout.
+func AllocAllN(n int) [][2]int {
+	nproc := mpi.WorldSize()
+	rngs := make([][2]int, nproc)
+	for r := range rngs {
+		st, end := balancedRange(n, nproc, r)
+		rngs[r] = [2]int{st, end}
+	}
+	return rngs
+}
+
+// AllocNCounts returns the number of items out of n allocated to each of the
+// WorldSize procs, in rank order, using the same balanced distribution as
+// AllocNBalanced, for use as the counts in the mpi Gatherv and Scatterv
+// methods, which handle n values that are not an even multiple of the
+// number of procs without padding.
+func AllocNCounts(n int) []int {
+	nproc := mpi.WorldSize()
+	counts := make([]int, nproc)
+	for r := range counts {
+		st, end := balancedRange(n, nproc, r)
+		counts[r] = end - st
+	}
+	return counts
+}
+
+// balancedRange returns the start and end (exclusive) range of n items
+// allocated to given rank out of nproc procs, with any remainder
+// allocated one each to the lowest ranks.
+func balancedRange(n, nproc, rank int) (st, end int) {
+	pt := n / nproc
+	rem := n % nproc
+	st = rank*pt + min(rank, rem)
+	end = st + pt
+	if rank < rem {
+		end++
+	}
+	return
+}
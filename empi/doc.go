@@ -12,7 +12,9 @@ The wrapping code was initially copied  from https://github.com/cpmech/gosl/mpi
 and significantly modified.
 
 All standard Go types are supported using the apache arrow tmpl generation tool.
-Int is assumed to be 64bit and is defined as a []int because that is typically
-more convenient.
+Int is defined as a []int because that is typically more convenient,
+and uses the MPI datatype matching the size of int on the current platform
+(64bit on 64bit platforms, 32bit otherwise).  Use the I32 or I64 methods
+to transfer fixed-size integers.
 */
 package empi
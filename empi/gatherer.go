@@ -0,0 +1,99 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package empi
+
+import "github.com/emer/empi/v2/mpi"
+
+// Gatherer is the minimal set of communication methods used by
+// GatherTensorRows and GatherTableRows, which is satisfied by *mpi.Comm.
+// It allows these functions to be used, and tested, without an MPI runtime,
+// e.g., using LocalComm.
+type Gatherer interface {
+
+	// Rank returns the rank of this proc.
+	Rank() int
+
+	// Size returns the number of procs.
+	Size() int
+
+	AllGatherU8(dest, orig []uint8) error
+	AllGatherI8(dest, orig []int8) error
+	AllGatherU16(dest, orig []uint16) error
+	AllGatherI16(dest, orig []int16) error
+	AllGatherU32(dest, orig []uint32) error
+	AllGatherI32(dest, orig []int32) error
+	AllGatherU64(dest, orig []uint64) error
+	AllGatherI64(dest, orig []int64) error
+	AllGatherInt(dest, orig []int) error
+	AllGatherF32(dest, orig []float32) error
+	AllGatherF64(dest, orig []float64) error
+}
+
+var _ Gatherer = (*mpi.Comm)(nil)
+
+// LocalComm is an in-memory, single-process implementation of Gatherer,
+// with Rank 0 and Size 1, where the AllGather methods copy orig into dest.
+// It is useful for testing code that uses GatherTensorRows and GatherTableRows
+// without an MPI runtime.
+type LocalComm struct{}
+
+func (lc *LocalComm) Rank() int { return 0 }
+
+func (lc *LocalComm) Size() int { return 1 }
+
+func (lc *LocalComm) AllGatherU8(dest, orig []uint8) error {
+	copy(dest, orig)
+	return nil
+}
+
+func (lc *LocalComm) AllGatherI8(dest, orig []int8) error {
+	copy(dest, orig)
+	return nil
+}
+
+func (lc *LocalComm) AllGatherU16(dest, orig []uint16) error {
+	copy(dest, orig)
+	return nil
+}
+
+func (lc *LocalComm) AllGatherI16(dest, orig []int16) error {
+	copy(dest, orig)
+	return nil
+}
+
+func (lc *LocalComm) AllGatherU32(dest, orig []uint32) error {
+	copy(dest, orig)
+	return nil
+}
+
+func (lc *LocalComm) AllGatherI32(dest, orig []int32) error {
+	copy(dest, orig)
+	return nil
+}
+
+func (lc *LocalComm) AllGatherU64(dest, orig []uint64) error {
+	copy(dest, orig)
+	return nil
+}
+
+func (lc *LocalComm) AllGatherI64(dest, orig []int64) error {
+	copy(dest, orig)
+	return nil
+}
+
+func (lc *LocalComm) AllGatherInt(dest, orig []int) error {
+	copy(dest, orig)
+	return nil
+}
+
+func (lc *LocalComm) AllGatherF32(dest, orig []float32) error {
+	copy(dest, orig)
+	return nil
+}
+
+func (lc *LocalComm) AllGatherF64(dest, orig []float64) error {
+	copy(dest, orig)
+	return nil
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+
+package empi
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/emer/empi/v2/mpi"
+	"github.com/emer/etable/v2/etensor"
+)
+
+// these tests are run under mpirun, e.g.:
+// mpirun -np 4 go test -tags mpi ./empi
+
+func TestMain(m *testing.M) {
+	mpi.Init()
+	code := m.Run()
+	mpi.Finalize()
+	os.Exit(code)
+}
+
+func TestReduceTensorInt(t *testing.T) {
+	comm, err := mpi.NewComm(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rank, np := comm.Rank(), comm.Size()
+	// use values beyond the 32 bit range where Go int is 64 bits
+	base := 0
+	if strconv.IntSize == 64 {
+		base = 1 << 40
+	}
+	src := etensor.NewInt([]int{2, 3}, nil, nil)
+	for i := range src.Values {
+		src.Values[i] = base + rank*10 - i
+	}
+	dest := etensor.NewInt([]int{2, 3}, nil, nil)
+	if err := ReduceTensor(dest, src, comm, mpi.OpSum); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range dest.Values {
+		want := np*(base-i) + 10*np*(np-1)/2
+		if v != want {
+			t.Errorf("proc %d: OpSum: index %d: got %d, want %d", rank, i, v, want)
+		}
+	}
+	if err := ReduceTensor(dest, src, comm, mpi.OpMax); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range dest.Values {
+		want := base + (np-1)*10 - i
+		if v != want {
+			t.Errorf("proc %d: OpMax: index %d: got %d, want %d", rank, i, v, want)
+		}
+	}
+}
+
+func TestScatterTensorRowsVShortSrc(t *testing.T) {
+	comm, err := mpi.NewComm(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	np := comm.Size()
+	counts := make([]int, np)
+	for i := range counts {
+		counts[i] = 2
+	}
+	// src is one row short on Root: all procs must return an error,
+	// instead of Root panicking and the others blocking.
+	src := etensor.NewString([]int{2*np - 1, 2}, nil, nil)
+	dest := etensor.NewString([]int{2, 2}, nil, nil)
+	if err := ScatterTensorRowsV(dest, src, counts, comm); err == nil {
+		t.Errorf("proc %d: expected error for short src", comm.Rank())
+	}
+}
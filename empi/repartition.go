@@ -0,0 +1,79 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package empi
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/emer/empi/v2/mpi"
+	"github.com/emer/etable/v2/etable"
+)
+
+// RepartitionTable redistributes the rows of the src tables on each proc,
+// from an old partitioning of the rows across oldNProc procs, to a new
+// partitioning across newNProc procs, returning a new table with the rows
+// for this proc.  This is needed when restarting from a checkpoint that was
+// saved with a different number of procs.  The rows on the procs with
+// rank < oldNProc are taken, in rank order, as the full set of rows,
+// and rows on any higher ranks are ignored.  The new partition allocates
+// contiguous ranges of rows to the procs with rank < newNProc, with any
+// remainder allocated one each to the lowest ranks, and other procs get
+// an empty table.  oldNProc and newNProc must be <= number of procs,
+// and all procs must have src tables with the same schema.
+func RepartitionTable(src *etable.Table, oldNProc, newNProc int, comm *mpi.Comm) (*etable.Table, error) {
+	np := comm.Size()
+	if oldNProc < 1 || oldNProc > np || newNProc < 1 || newNProc > np {
+		err := fmt.Errorf("empi.RepartitionTable: oldNProc: %d and newNProc: %d must be between 1 and number of MPI procs: %d", oldNProc, newNProc, np)
+		log.Println(err)
+		return nil, err
+	}
+	if np == 1 {
+		return src.Clone(), nil
+	}
+	rank := comm.Rank()
+	nr := src.Rows
+	if rank >= oldNProc {
+		nr = 0
+	}
+	counts := make([]int, np)
+	err := comm.AllGatherInt(counts, []int{nr})
+	if err != nil {
+		return nil, err
+	}
+	n := 0
+	starts := make([]int, np)
+	for p, c := range counts {
+		starts[p] = n
+		n += c
+	}
+	st, ed := 0, 0
+	if rank < newNProc {
+		st, ed = balancedRange(n, newNProc, rank)
+	}
+	sendRows := make([]int, np)
+	recvRows := make([]int, np)
+	for p := 0; p < np; p++ {
+		if p < newNProc {
+			pst, ped := balancedRange(n, newNProc, p)
+			sendRows[p] = rowsOverlap(starts[rank], starts[rank]+nr, pst, ped)
+		}
+		recvRows[p] = rowsOverlap(starts[p], starts[p]+counts[p], st, ed)
+	}
+	dest := etable.New(src.Schema(), ed-st)
+	for ci, sc := range src.Cols {
+		err = exchangeTensorRows(dest.Cols[ci], sc, sendRows, recvRows, comm)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dest, nil
+}
+
+// rowsOverlap returns the number of rows in common between
+// the row ranges [st, ed) and [ost, oed).
+func rowsOverlap(st, ed, ost, oed int) int {
+	return max(0, min(ed, oed)-max(st, ost))
+}
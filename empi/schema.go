@@ -0,0 +1,59 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package empi
+
+import (
+	"fmt"
+	"hash/fnv"
+	"log"
+
+	"github.com/emer/empi/v2/mpi"
+	"github.com/emer/etable/v2/etable"
+)
+
+// AssertSameSchema checks that the given table has the same structure
+// (column names, data types, and cell shapes) on all procs, as required
+// for GatherTableRows and ReduceTable, returning an error on all procs,
+// naming the first mismatched column, if they differ.
+// It all-reduces the min and max of a hash of each column's structure,
+// so it must be called on all procs.
+func AssertSameSchema(dt *etable.Table, comm *mpi.Comm) error {
+	nc := len(dt.Cols)
+	err := comm.AssertSameInt(nc)
+	if err != nil {
+		err = fmt.Errorf("empi.AssertSameSchema: procs have different numbers of columns: %w", err)
+		log.Println(err)
+		return err
+	}
+	if nc == 0 || comm.Size() == 1 {
+		return nil
+	}
+	sc := dt.Schema()
+	hs := make([]uint64, nc)
+	for i, c := range sc {
+		h := fnv.New64a()
+		fmt.Fprintf(h, "%s:%d:%v", c.Name, c.Type, c.CellShape)
+		hs[i] = h.Sum64()
+	}
+	mn := make([]uint64, nc)
+	mx := make([]uint64, nc)
+	err = comm.AllReduceU64(mpi.OpMin, mn, hs)
+	if err != nil {
+		return err
+	}
+	err = comm.AllReduceU64(mpi.OpMax, mx, hs)
+	if err != nil {
+		return err
+	}
+	for i := range hs {
+		if mn[i] != mx[i] {
+			c := sc[i]
+			err = fmt.Errorf("empi.AssertSameSchema: procs disagree on column %d (this proc: %d: name: %s, type: %v, cell shape: %v)", i, comm.Rank(), c.Name, c.Type, c.CellShape)
+			log.Println(err)
+			return err
+		}
+	}
+	return nil
+}
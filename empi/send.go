@@ -0,0 +1,284 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package empi
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/emer/empi/v2/mpi"
+	"github.com/emer/etable/v2/etensor"
+)
+
+// SendTensor sends the shape and values of given tensor to toProc,
+// using given unique tag identifier.  All data types are supported,
+// including STRING, where the string lengths are sent followed by the
+// concatenated string bytes, and BOOL, which is sent as one byte per value.
+// Numeric values are sent directly from the tensor's Values slice, without
+// any intermediate copy, as etensor tensors are always stored contiguously.
+// This is Blocking. Must have a corresponding RecvTensor call with
+// same tag on toProc, from this proc.
+func SendTensor(tsr etensor.Tensor, toProc, tag int, comm *mpi.Comm) error {
+	shp := tsr.Shapes()
+	err := comm.SendInt(toProc, tag, []int{len(shp)})
+	if err != nil {
+		return err
+	}
+	if len(shp) > 0 {
+		err = comm.SendInt(toProc, tag, shp)
+		if err != nil {
+			return err
+		}
+	}
+	if tsr.Len() == 0 {
+		return nil
+	}
+	switch tsr.DataType() {
+	case etensor.STRING:
+		st := tsr.(*etensor.String)
+		sln := make([]int, len(st.Values))
+		var sdt []byte
+		for i, s := range st.Values {
+			sln[i] = len(s)
+			sdt = append(sdt, s...)
+		}
+		err = comm.SendInt(toProc, tag, sln)
+		if err == nil && len(sdt) > 0 {
+			err = comm.SendU8(toProc, tag, sdt)
+		}
+	case etensor.BOOL:
+		st := tsr.(*etensor.Bits)
+		sb := make([]uint8, st.Len())
+		for i := range sb {
+			if st.Value1D(i) {
+				sb[i] = 1
+			}
+		}
+		err = comm.SendU8(toProc, tag, sb)
+	case etensor.UINT8:
+		err = comm.SendU8(toProc, tag, tsr.(*etensor.Uint8).Values)
+	case etensor.INT8:
+		err = comm.SendI8(toProc, tag, tsr.(*etensor.Int8).Values)
+	case etensor.UINT16:
+		err = comm.SendU16(toProc, tag, tsr.(*etensor.Uint16).Values)
+	case etensor.INT16:
+		err = comm.SendI16(toProc, tag, tsr.(*etensor.Int16).Values)
+	case etensor.UINT32:
+		err = comm.SendU32(toProc, tag, tsr.(*etensor.Uint32).Values)
+	case etensor.INT32:
+		err = comm.SendI32(toProc, tag, tsr.(*etensor.Int32).Values)
+	case etensor.UINT64:
+		err = comm.SendU64(toProc, tag, tsr.(*etensor.Uint64).Values)
+	case etensor.INT64:
+		err = comm.SendI64(toProc, tag, tsr.(*etensor.Int64).Values)
+	case etensor.INT:
+		err = comm.SendInt(toProc, tag, tsr.(*etensor.Int).Values)
+	case etensor.FLOAT32:
+		err = comm.SendF32(toProc, tag, tsr.(*etensor.Float32).Values)
+	case etensor.FLOAT64:
+		err = comm.SendF64(toProc, tag, tsr.(*etensor.Float64).Values)
+	default:
+		err = fmt.Errorf("empi.SendTensor: data type not supported: %v", tsr.DataType())
+		log.Println(err)
+	}
+	return err
+}
+
+// RecvTensor receives the shape and values of a tensor sent by SendTensor
+// from proc fmProc, using given unique tag identifier, into given tensor,
+// which must be of the same data type as the sent tensor, and is reshaped
+// to the sent shape (keeping its dimension names if the number of
+// dimensions is the same).  fmProc must be a specific proc, not mpi.AnySource,
+// because the tensor is received in multiple messages.
+// Numeric values are received directly into the tensor's Values slice.
+// This is Blocking. Must have a corresponding SendTensor call with
+// same tag on fmProc, to this proc.
+func RecvTensor(tsr etensor.Tensor, fmProc, tag int, comm *mpi.Comm) error {
+	nd := []int{0}
+	err := comm.RecvInt(fmProc, tag, nd)
+	if err != nil {
+		return err
+	}
+	shp := make([]int, nd[0])
+	if nd[0] > 0 {
+		err = comm.RecvInt(fmProc, tag, shp)
+		if err != nil {
+			return err
+		}
+	}
+	var nms []string
+	if tsr.NumDims() == nd[0] {
+		nms = tsr.DimNames()
+	}
+	tsr.SetShape(shp, nil, nms)
+	if tsr.Len() == 0 {
+		return nil
+	}
+	switch tsr.DataType() {
+	case etensor.STRING:
+		dt := tsr.(*etensor.String)
+		dln := make([]int, len(dt.Values))
+		err = comm.RecvInt(fmProc, tag, dln)
+		if err != nil {
+			return err
+		}
+		dsz := 0
+		for _, l := range dln {
+			dsz += l
+		}
+		ddt := make([]byte, dsz)
+		if dsz > 0 {
+			err = comm.RecvU8(fmProc, tag, ddt)
+		}
+		idx := 0
+		for i, l := range dln {
+			dt.Values[i] = string(ddt[idx : idx+l])
+			idx += l
+		}
+	case etensor.BOOL:
+		dt := tsr.(*etensor.Bits)
+		db := make([]uint8, dt.Len())
+		err = comm.RecvU8(fmProc, tag, db)
+		for i, b := range db {
+			dt.Set1D(i, b != 0)
+		}
+	case etensor.UINT8:
+		err = comm.RecvU8(fmProc, tag, tsr.(*etensor.Uint8).Values)
+	case etensor.INT8:
+		err = comm.RecvI8(fmProc, tag, tsr.(*etensor.Int8).Values)
+	case etensor.UINT16:
+		err = comm.RecvU16(fmProc, tag, tsr.(*etensor.Uint16).Values)
+	case etensor.INT16:
+		err = comm.RecvI16(fmProc, tag, tsr.(*etensor.Int16).Values)
+	case etensor.UINT32:
+		err = comm.RecvU32(fmProc, tag, tsr.(*etensor.Uint32).Values)
+	case etensor.INT32:
+		err = comm.RecvI32(fmProc, tag, tsr.(*etensor.Int32).Values)
+	case etensor.UINT64:
+		err = comm.RecvU64(fmProc, tag, tsr.(*etensor.Uint64).Values)
+	case etensor.INT64:
+		err = comm.RecvI64(fmProc, tag, tsr.(*etensor.Int64).Values)
+	case etensor.INT:
+		err = comm.RecvInt(fmProc, tag, tsr.(*etensor.Int).Values)
+	case etensor.FLOAT32:
+		err = comm.RecvF32(fmProc, tag, tsr.(*etensor.Float32).Values)
+	case etensor.FLOAT64:
+		err = comm.RecvF64(fmProc, tag, tsr.(*etensor.Float64).Values)
+	default:
+		err = fmt.Errorf("empi.RecvTensor: data type not supported: %v", tsr.DataType())
+		log.Println(err)
+	}
+	return err
+}
+
+// BcastTensor broadcasts the shape and values of given tensor from proc
+// fmProc to all other procs, where the tensor must be of the same data type,
+// and is reshaped to the broadcast shape (keeping its dimension names if the
+// number of dimensions is the same), using mpi.Comm.BcastShape.
+// All data types are supported, as in SendTensor.  Numeric values are
+// broadcast directly from and into the tensor's Values slice, without
+// any intermediate copy.  It must be called on all procs.
+func BcastTensor(tsr etensor.Tensor, fmProc int, comm *mpi.Comm) error {
+	if comm.Size() == 1 {
+		return nil
+	}
+	isFrom := comm.Rank() == fmProc
+	var shp []int
+	if isFrom {
+		shp = tsr.Shapes()
+	}
+	err := comm.BcastShape(fmProc, &shp)
+	if err != nil {
+		return err
+	}
+	if !isFrom {
+		var nms []string
+		if tsr.NumDims() == len(shp) {
+			nms = tsr.DimNames()
+		}
+		tsr.SetShape(shp, nil, nms)
+	}
+	if tsr.Len() == 0 {
+		return nil
+	}
+	switch tsr.DataType() {
+	case etensor.STRING:
+		st := tsr.(*etensor.String)
+		sln := make([]int, len(st.Values))
+		var sdt []byte
+		if isFrom {
+			for i, s := range st.Values {
+				sln[i] = len(s)
+				sdt = append(sdt, s...)
+			}
+		}
+		err = comm.BcastInt(fmProc, sln)
+		if err != nil {
+			return err
+		}
+		if !isFrom {
+			dsz := 0
+			for _, l := range sln {
+				dsz += l
+			}
+			sdt = make([]byte, dsz)
+		}
+		if len(sdt) > 0 {
+			err = comm.BcastU8(fmProc, sdt)
+			if err != nil {
+				return err
+			}
+		}
+		if !isFrom {
+			idx := 0
+			for i, l := range sln {
+				st.Values[i] = string(sdt[idx : idx+l])
+				idx += l
+			}
+		}
+	case etensor.BOOL:
+		st := tsr.(*etensor.Bits)
+		sb := make([]uint8, st.Len())
+		if isFrom {
+			for i := range sb {
+				if st.Value1D(i) {
+					sb[i] = 1
+				}
+			}
+		}
+		err = comm.BcastU8(fmProc, sb)
+		if err == nil && !isFrom {
+			for i, b := range sb {
+				st.Set1D(i, b != 0)
+			}
+		}
+	case etensor.UINT8:
+		err = comm.BcastU8(fmProc, tsr.(*etensor.Uint8).Values)
+	case etensor.INT8:
+		err = comm.BcastI8(fmProc, tsr.(*etensor.Int8).Values)
+	case etensor.UINT16:
+		err = comm.BcastU16(fmProc, tsr.(*etensor.Uint16).Values)
+	case etensor.INT16:
+		err = comm.BcastI16(fmProc, tsr.(*etensor.Int16).Values)
+	case etensor.UINT32:
+		err = comm.BcastU32(fmProc, tsr.(*etensor.Uint32).Values)
+	case etensor.INT32:
+		err = comm.BcastI32(fmProc, tsr.(*etensor.Int32).Values)
+	case etensor.UINT64:
+		err = comm.BcastU64(fmProc, tsr.(*etensor.Uint64).Values)
+	case etensor.INT64:
+		err = comm.BcastI64(fmProc, tsr.(*etensor.Int64).Values)
+	case etensor.INT:
+		err = comm.BcastInt(fmProc, tsr.(*etensor.Int).Values)
+	case etensor.FLOAT32:
+		err = comm.BcastF32(fmProc, tsr.(*etensor.Float32).Values)
+	case etensor.FLOAT64:
+		err = comm.BcastF64(fmProc, tsr.(*etensor.Float64).Values)
+	default:
+		err = fmt.Errorf("empi.BcastTensor: data type not supported: %v", tsr.DataType())
+		log.Println(err)
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package empi
+
+import (
+	"sort"
+
+	"github.com/emer/empi/v2/mpi"
+	"github.com/emer/etable/v2/etable"
+)
+
+// ShuffleRedistribute globally shuffles the rows of the given table across
+// all procs, in place, e.g., at the start of each epoch for data-parallel
+// training, so that each proc sees a different random subset of the full
+// set of rows, instead of just a reordering of its own rows.
+// The seed on the Root proc is used to compute a random permutation of all
+// the rows (taken in rank order), which is shared with all procs using
+// mpi.Comm.SharedPerm, and rows are then moved to their new owner procs
+// using AllToAllv.
+// Each proc keeps the same number of rows it started with, and all procs
+// must have tables with the same schema.
+func ShuffleRedistribute(dt *etable.Table, seed int64, comm *mpi.Comm) error {
+	np := comm.Size()
+	rank := comm.Rank()
+	counts := []int{dt.Rows}
+	if np > 1 {
+		counts = make([]int, np)
+		err := comm.AllGatherInt(counts, []int{dt.Rows})
+		if err != nil {
+			return err
+		}
+	}
+	starts, n := mpi.Displacements(counts)
+	perm, err := comm.SharedPerm(n, seed)
+	if err != nil {
+		return err
+	}
+	owner := func(g int) int { // proc holding global row g, skipping empty procs
+		return sort.Search(np, func(p int) bool { return starts[p]+counts[p] > g })
+	}
+	st := starts[rank]
+	// rows sent in order of new owner, then new position
+	sendIdx := make([]int, dt.Rows)
+	for i := range sendIdx {
+		sendIdx[i] = i
+	}
+	sort.Slice(sendIdx, func(a, b int) bool {
+		return perm[st+sendIdx[a]] < perm[st+sendIdx[b]]
+	})
+	sendRows := make([]int, np)
+	for _, i := range sendIdx {
+		sendRows[owner(perm[st+i])]++
+	}
+	// rows received in order of sender, then new position
+	inv := make([]int, n)
+	for g, p := range perm {
+		inv[p] = g
+	}
+	recvPos := make([]int, 0, dt.Rows)
+	recvRows := make([]int, np)
+	for s := 0; s < np; s++ {
+		for p := st; p < st+dt.Rows; p++ {
+			g := inv[p]
+			if g >= starts[s] && g < starts[s]+counts[s] {
+				recvPos = append(recvPos, p-st)
+				recvRows[s]++
+			}
+		}
+	}
+	for _, col := range dt.Cols {
+		_, cells := col.RowCellSize()
+		send := col.Clone()
+		for r, i := range sendIdx {
+			send.CopyCellsFrom(col, r*cells, i*cells, cells)
+		}
+		recv := col.Clone()
+		if np > 1 {
+			err := exchangeTensorRows(recv, send, sendRows, recvRows, comm)
+			if err != nil {
+				return err
+			}
+		} else {
+			recv = send
+		}
+		for r, p := range recvPos {
+			col.CopyCellsFrom(recv, p*cells, r*cells, cells)
+		}
+	}
+	return nil
+}
@@ -5,16 +5,28 @@
 package empi
 
 import (
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math"
+	"sort"
+
 	"github.com/emer/empi/v2/mpi"
 	"github.com/emer/etable/v2/etable"
+	"github.com/emer/etable/v2/etensor"
 )
 
+// RankColName is the name of the column added by GatherTableRowsRank,
+// recording the source rank of each gathered row.
+const RankColName = "MPIRank"
+
 // GatherTableRows does an MPI AllGather on given src table data, gathering into dest.
 // dest will have np * src.Rows Rows, filled with each processor's data, in order.
 // dest must be a clone of src: if not same number of cols, will be configured from src.
-func GatherTableRows(dest, src *etable.Table, comm *mpi.Comm) {
+// comm can be any Gatherer, such as *mpi.Comm.
+func GatherTableRows(dest, src *etable.Table, comm Gatherer) {
 	sr := src.Rows
-	np := mpi.WorldSize()
+	np := comm.Size()
 	dr := np * sr
 	if len(dest.Cols) != len(src.Cols) {
 		dest.SetFromSchema(src.Schema(), dr)
@@ -27,6 +39,35 @@ func GatherTableRows(dest, src *etable.Table, comm *mpi.Comm) {
 	}
 }
 
+// GatherTableRowsRank does GatherTableRows on given src table data, gathering
+// into dest, and also fills an additional int column named RankColName
+// (MPIRank) at the end of dest, recording the rank of the proc that each row
+// came from.  This is useful for debugging load imbalance and for per-rank
+// analysis of gathered logs.  dest must be a clone of src plus the MPIRank
+// column: if not, it will be configured from src, with the MPIRank column added.
+func GatherTableRowsRank(dest, src *etable.Table, comm Gatherer) {
+	sr := src.Rows
+	np := comm.Size()
+	dr := np * sr
+	if len(dest.Cols) != len(src.Cols)+1 || dest.ColName(len(src.Cols)) != RankColName {
+		sc := src.Schema()
+		sc = append(sc, etable.Column{Name: RankColName, Type: etensor.INT64})
+		dest.SetFromSchema(sc, dr)
+	} else {
+		dest.SetNumRows(dr)
+	}
+	for ci, st := range src.Cols {
+		dt := dest.Cols[ci]
+		GatherTensorRows(dt, st, comm)
+	}
+	rc := dest.Cols[len(src.Cols)]
+	for r := 0; r < np; r++ {
+		for i := 0; i < sr; i++ {
+			rc.SetFloat1D(r*sr+i, float64(r))
+		}
+	}
+}
+
 // ReduceTable does an MPI AllReduce on given src table data using given operation,
 // gathering into dest.
 // each processor must have the same table organization -- the tensor values are
@@ -45,3 +86,102 @@ func ReduceTable(dest, src *etable.Table, comm *mpi.Comm, op mpi.Op) {
 		ReduceTensor(dt, st, comm, op)
 	}
 }
+
+// AllReduceTableColArgMin finds the global minimum value of the given column
+// across the tables on all procs, e.g., for selecting the best-performing
+// configuration, returning the rank of the proc and the row in its local
+// table holding the minimum, along with the value, on all procs.
+// NaN values are ignored.  In case of ties, the lowest rank, and the
+// lowest row within that rank, is returned.  If there are no valid values
+// on any proc, rank and row are -1 and val is +Inf.
+func AllReduceTableColArgMin(dt *etable.Table, colName string, comm *mpi.Comm) (rank, row int, val float64, err error) {
+	col, err := dt.ColByNameTry(colName)
+	if err != nil {
+		err = fmt.Errorf("empi.AllReduceTableColArgMin: %w", err)
+		log.Println(err)
+		return -1, -1, 0, err
+	}
+	_, cells := col.RowCellSize()
+	mn := math.Inf(1)
+	mrow := -1
+	for i := 0; i < col.Len(); i++ {
+		v := col.FloatVal1D(i)
+		if !math.IsNaN(v) && (mrow < 0 || v < mn) {
+			mn = v
+			mrow = i / cells
+		}
+	}
+	myRank := comm.Rank()
+	if mrow < 0 {
+		myRank = comm.Size() // no valid values: lose all ties
+	}
+	if comm.Size() == 1 {
+		if mrow < 0 {
+			return -1, -1, mn, nil
+		}
+		return 0, mrow, mn, nil
+	}
+	gmn := []float64{0}
+	grank := []int{0}
+	err = comm.AllReduceMinLocF64(gmn, []float64{mn}, grank, []int{myRank})
+	if err != nil {
+		return -1, -1, 0, err
+	}
+	rank, val = grank[0], gmn[0]
+	if rank >= comm.Size() {
+		return -1, -1, val, nil
+	}
+	rw := []int{mrow}
+	err = comm.BcastInt(rank, rw)
+	return rank, rw[0], val, err
+}
+
+// GatherScalarsToTable gathers the named scalar metrics from all procs,
+// e.g., per-proc diagnostics such as timing or loss, into a table on the
+// Root proc, with one row per rank and one float64 column per metric,
+// in sorted name order, preceded by an int column named RankColName
+// (MPIRank).  All procs must pass the same metric names, which is checked.
+// The table is returned only on the Root proc: other procs get nil.
+func GatherScalarsToTable(metrics map[string]float64, comm *mpi.Comm) (*etable.Table, error) {
+	names := make([]string, 0, len(metrics))
+	for nm := range metrics {
+		names = append(names, nm)
+	}
+	sort.Strings(names)
+	h := fnv.New64a()
+	for _, nm := range names {
+		fmt.Fprintf(h, "%s\n", nm)
+	}
+	if err := comm.AssertSameInt(int(h.Sum64())); err != nil {
+		err = fmt.Errorf("empi.GatherScalarsToTable: procs have different metric names (this proc: %v): %w", names, err)
+		log.Println(err)
+		return nil, err
+	}
+	nc := len(names)
+	np := comm.Size()
+	vals := make([]float64, nc)
+	for i, nm := range names {
+		vals[i] = metrics[nm]
+	}
+	all := make([]float64, np*nc)
+	if nc > 0 {
+		if err := comm.GatherF64(mpi.Root, all, vals); err != nil {
+			return nil, err
+		}
+	}
+	if !comm.IsRoot() {
+		return nil, nil
+	}
+	sc := etable.Schema{etable.Column{Name: RankColName, Type: etensor.INT64}}
+	for _, nm := range names {
+		sc = append(sc, etable.Column{Name: nm, Type: etensor.FLOAT64})
+	}
+	dt := etable.New(sc, np)
+	for r := 0; r < np; r++ {
+		dt.Cols[0].SetFloat1D(r, float64(r))
+		for i := 0; i < nc; i++ {
+			dt.Cols[i+1].SetFloat1D(r, all[r*nc+i])
+		}
+	}
+	return dt, nil
+}
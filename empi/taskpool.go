@@ -0,0 +1,121 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package empi
+
+import (
+	"github.com/emer/empi/v2/mpi"
+)
+
+// message tags used by the task pool protocol, on its private communicator
+const (
+	taskPoolTaskTag = 7100 + iota
+	taskPoolDoneTag
+	taskPoolDataTag
+)
+
+// RunTaskPool distributes nTasks tasks across procs using a master-worker
+// pattern: the Root proc hands out task indices to the worker (non-root) procs,
+// giving each worker a new task as soon as it reports completion of its
+// previous one, until all tasks are done.  This balances the load automatically
+// when tasks take variable amounts of time.
+// worker is called on the worker procs with each task index, and returns
+// the results for that task, which can be of any length.
+// The results are returned on the Root proc, indexed by task,
+// and are nil on all other procs.
+// If there is only one proc, all tasks are run on Root directly.
+// The messages are sent on a duplicate of comm, so that they cannot
+// interfere with any other messages on comm.  This must be called on all procs.
+func RunTaskPool(comm *mpi.Comm, nTasks int, worker func(task int) []float32) (res [][]float32, err error) {
+	if comm.Size() == 1 {
+		res = make([][]float32, nTasks)
+		for t := range res {
+			res[t] = worker(t)
+		}
+		return res, nil
+	}
+	pc, err := comm.Dup()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if ferr := pc.Free(); err == nil {
+			err = ferr
+		}
+	}()
+	if pc.Rank() != mpi.Root {
+		return nil, taskPoolWorker(pc, worker)
+	}
+	return taskPoolRoot(pc, nTasks)
+}
+
+// taskPoolRoot hands out tasks to workers as they report in, using AnySource
+// receives, and collects the results.  A negative task index tells the
+// worker to stop.
+func taskPoolRoot(comm *mpi.Comm, nTasks int) ([][]float32, error) {
+	np := comm.Size()
+	res := make([][]float32, nTasks)
+	next := 0
+	active := 0
+	task := []int{0}
+	nextTask := func() {
+		task[0] = -1
+		if next < nTasks {
+			task[0] = next
+			next++
+			active++
+		}
+	}
+	for w := 1; w < np; w++ {
+		nextTask()
+		if err := comm.SendInt(w, taskPoolTaskTag, task); err != nil {
+			return nil, err
+		}
+	}
+	hdr := make([]int, 3) // worker rank, task, number of results
+	for active > 0 {
+		if err := comm.RecvInt(mpi.AnySource, taskPoolDoneTag, hdr); err != nil {
+			return nil, err
+		}
+		w, t, n := hdr[0], hdr[1], hdr[2]
+		active--
+		vals := make([]float32, n)
+		if n > 0 {
+			if err := comm.RecvF32(w, taskPoolDataTag, vals); err != nil {
+				return nil, err
+			}
+		}
+		res[t] = vals
+		nextTask()
+		if err := comm.SendInt(w, taskPoolTaskTag, task); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// taskPoolWorker runs tasks received from Root until told to stop,
+// sending back the results of each.
+func taskPoolWorker(comm *mpi.Comm, worker func(task int) []float32) error {
+	task := []int{0}
+	hdr := make([]int, 3)
+	for {
+		if err := comm.RecvInt(mpi.Root, taskPoolTaskTag, task); err != nil {
+			return err
+		}
+		if task[0] < 0 {
+			return nil
+		}
+		vals := worker(task[0])
+		hdr[0], hdr[1], hdr[2] = comm.Rank(), task[0], len(vals)
+		if err := comm.SendInt(mpi.Root, taskPoolDoneTag, hdr); err != nil {
+			return err
+		}
+		if len(vals) > 0 {
+			if err := comm.SendF32(mpi.Root, taskPoolDataTag, vals); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -5,6 +5,9 @@
 package empi
 
 import (
+	"fmt"
+	"log"
+
 	"github.com/emer/empi/v2/mpi"
 	"github.com/emer/etable/v2/etensor"
 )
@@ -13,14 +16,16 @@ import (
 // using a row-based tensor organization (as in an etable.Table).
 // dest will have np * src.Rows Rows, filled with each processor's data, in order.
 // dest must have same overall shape as src at start, but rows will be enforced.
-func GatherTensorRows(dest, src etensor.Tensor, comm *mpi.Comm) error {
+// comm can be any Gatherer, such as *mpi.Comm.
+// All etensor data types are supported, and an error is returned for any other.
+func GatherTensorRows(dest, src etensor.Tensor, comm Gatherer) error {
 	dt := src.DataType()
 	if dt == etensor.STRING {
 		return GatherTensorRowsString(dest.(*etensor.String), src.(*etensor.String), comm)
 	}
 	sr, _ := src.RowCellSize()
 	dr, _ := dest.RowCellSize()
-	np := mpi.WorldSize()
+	np := comm.Size()
 	dl := np * sr
 	if dr != dl {
 		dest.SetNumRows(dl)
@@ -30,7 +35,7 @@ func GatherTensorRows(dest, src etensor.Tensor, comm *mpi.Comm) error {
 	var err error
 	switch dt {
 	case etensor.BOOL:
-		// todo
+		err = gatherTensorRowsBits(dest.(*etensor.Bits), src.(*etensor.Bits), comm)
 	case etensor.UINT8:
 		dt := dest.(*etensor.Uint8)
 		st := src.(*etensor.Uint8)
@@ -75,18 +80,52 @@ func GatherTensorRows(dest, src etensor.Tensor, comm *mpi.Comm) error {
 		dt := dest.(*etensor.Float64)
 		st := src.(*etensor.Float64)
 		err = comm.AllGatherF64(dt.Values, st.Values)
+	default:
+		err = fmt.Errorf("empi.GatherTensorRows: data type not supported: %v", dt)
+		log.Println(err)
 	}
 	return err
 }
 
+// gatherTensorRowsBits does GatherTensorRows for BOOL tensors, packing the
+// bits into bytes for the transfer.  Each proc's bits are packed starting at
+// a byte boundary, and the number of bits per proc need not be a multiple
+// of 8, so they are unpacked into dest at the corresponding bit offset.
+func gatherTensorRowsBits(dest, src *etensor.Bits, comm Gatherer) error {
+	n := src.Len()
+	nb := (n + 7) / 8
+	if nb == 0 {
+		return nil
+	}
+	sb := make([]uint8, nb)
+	for i := 0; i < n; i++ {
+		if src.Value1D(i) {
+			sb[i/8] |= 1 << (i % 8)
+		}
+	}
+	np := comm.Size()
+	db := make([]uint8, np*nb)
+	err := comm.AllGatherU8(db, sb)
+	if err != nil {
+		return err
+	}
+	for p := 0; p < np; p++ {
+		pb := db[p*nb : (p+1)*nb]
+		for i := 0; i < n; i++ {
+			dest.Set1D(p*n+i, pb[i/8]&(1<<(i%8)) != 0)
+		}
+	}
+	return nil
+}
+
 // GatherTensorRowsString does an MPI AllGather on given String src tensor data,
 // gathering into dest, using a row-based tensor organization (as in an etable.Table).
 // dest will have np * src.Rows Rows, filled with each processor's data, in order.
 // dest must have same overall shape as src at start, but rows will be enforced.
-func GatherTensorRowsString(dest, src *etensor.String, comm *mpi.Comm) error {
+func GatherTensorRowsString(dest, src *etensor.String, comm Gatherer) error {
 	sr, _ := src.RowCellSize()
 	dr, _ := dest.RowCellSize()
-	np := mpi.WorldSize()
+	np := comm.Size()
 	dl := np * sr
 	if dr != dl {
 		dest.SetNumRows(dl)
@@ -129,11 +168,490 @@ func GatherTensorRowsString(dest, src *etensor.String, comm *mpi.Comm) error {
 	return err
 }
 
+// GatherTensorRowsStringTo does an MPI Gatherv on given String src tensor data,
+// gathering into dest on toProc only, using a row-based tensor organization
+// (as in an etable.Table).  Unlike GatherTensorRowsString, only the exact
+// string bytes are transferred, without padding, and only to toProc,
+// which is more efficient when the strings are only needed on one proc,
+// e.g., for logging.  Each processor can have a different number of rows.
+// dest will have the total number of rows from all processors, filled
+// with each processor's data, in order, and is only used on toProc.
+// dest must have same cell shape as src, but rows will be enforced.
+func GatherTensorRowsStringTo(toProc int, dest, src *etensor.String, comm *mpi.Comm) error {
+	np := comm.Size()
+	if np == 1 {
+		dest.CopyShapeFrom(src)
+		dest.CopyFrom(src)
+		return nil
+	}
+	isTo := comm.Rank() == toProc
+	sr, _ := src.RowCellSize()
+	var rows, counts []int
+	if isTo {
+		rows = make([]int, np)
+		counts = make([]int, np)
+	}
+	err := comm.GatherInt(toProc, rows, []int{sr})
+	if err != nil {
+		return err
+	}
+	err = comm.GatherInt(toProc, counts, []int{len(src.Values)})
+	if err != nil {
+		return err
+	}
+	sln := make([]int, len(src.Values))
+	var sdt []byte
+	for i, s := range src.Values {
+		sln[i] = len(s)
+		sdt = append(sdt, s...)
+	}
+	var dln, bcounts []int
+	if isTo {
+		dr := 0
+		for _, r := range rows {
+			dr += r
+		}
+		dest.SetNumRows(dr)
+		dln = make([]int, len(dest.Values))
+		bcounts = make([]int, np)
+	}
+	err = comm.GathervInt(toProc, dln, sln, counts, nil)
+	if err != nil {
+		return err
+	}
+	dsz := 0
+	if isTo {
+		idx := 0
+		for p, c := range counts {
+			for _, l := range dln[idx : idx+c] {
+				bcounts[p] += l
+			}
+			idx += c
+			dsz += bcounts[p]
+		}
+	}
+	ddt := make([]byte, dsz)
+	err = comm.GathervU8(toProc, ddt, sdt, bcounts, nil)
+	if err != nil || !isTo {
+		return err
+	}
+	idx := 0
+	for i, l := range dln {
+		dest.Values[i] = string(ddt[idx : idx+l])
+		idx += l
+	}
+	return nil
+}
+
+// GatherTensorRowsPadded does an MPI AllGather on given src tensor data, gathering into dest,
+// using a row-based tensor organization (as in an etable.Table), like GatherTensorRows,
+// except that each processor can have a different number of rows.
+// Each processor's rows are padded (with zero values) up to the max number of rows
+// across processors, gathered, and the padding is then removed, so that dest has
+// the total number of rows from all processors, filled with each processor's data, in order.
+// Returns the number of rows from each processor, for slicing the results.
+// dest must have same cell shape as src, but rows will be enforced.
+func GatherTensorRowsPadded(dest, src etensor.Tensor, comm *mpi.Comm) ([]int, error) {
+	np := comm.Size()
+	sr, cells := src.RowCellSize()
+	if np == 1 {
+		dest.CopyShapeFrom(src)
+		dest.CopyFrom(src)
+		return []int{sr}, nil
+	}
+	counts := make([]int, np)
+	err := comm.AllGatherInt(counts, []int{sr})
+	if err != nil {
+		return nil, err
+	}
+	mx := 0
+	total := 0
+	for _, c := range counts {
+		mx = max(mx, c)
+		total += c
+	}
+	psrc := src.Clone()
+	psrc.SetNumRows(mx)
+	pdest := src.Clone()
+	err = GatherTensorRows(pdest, psrc, comm)
+	if err != nil {
+		return counts, err
+	}
+	dest.SetNumRows(total)
+	off := 0
+	for p, c := range counts {
+		dest.CopyCellsFrom(pdest, off*cells, p*mx*cells, c*cells)
+		off += c
+	}
+	return counts, nil
+}
+
+// GatherTensorRowsSel does an MPI AllGather of only the selected rows of
+// given src tensor data, as given by rowIdxs, gathering into dest, using a
+// row-based tensor organization (as in an etable.Table), e.g., to gather
+// only the rows for misclassified examples.  Each processor can select a
+// different number of rows, and only the selected rows are transferred.
+// dest will have the total number of selected rows from all processors,
+// filled with each processor's selected rows, in order.
+// dest must have same cell shape as src, but rows will be enforced.
+func GatherTensorRowsSel(dest, src etensor.Tensor, rowIdxs []int, comm *mpi.Comm) error {
+	sr, cells := src.RowCellSize()
+	shp := append([]int{len(rowIdxs)}, src.Shapes()[1:]...)
+	sel := etensor.New(src.DataType(), shp, nil, src.DimNames())
+	for i, ri := range rowIdxs {
+		if ri < 0 || ri >= sr {
+			err := fmt.Errorf("empi.GatherTensorRowsSel: row index %d out of range for rows: %d", ri, sr)
+			log.Println(err)
+			return err
+		}
+		sel.CopyCellsFrom(src, i*cells, ri*cells, cells)
+	}
+	np := comm.Size()
+	if np == 1 {
+		dest.CopyShapeFrom(sel)
+		dest.CopyFrom(sel)
+		return nil
+	}
+	counts := make([]int, np)
+	err := comm.AllGatherInt(counts, []int{len(rowIdxs)})
+	if err != nil {
+		return err
+	}
+	return allGatherTensorRowsV(dest, sel, counts, comm)
+}
+
+// allGatherTensorRowsV does an MPI AllGatherv on given src tensor data,
+// gathering into dest, using a row-based tensor organization (as in an etable.Table),
+// where rows[i] is the number of rows on proc i.  dest must have the same cell shape
+// as src, and its number of rows is set to the total of rows.
+func allGatherTensorRowsV(dest, src etensor.Tensor, rows []int, comm *mpi.Comm) error {
+	_, cells := src.RowCellSize()
+	rc := make([]int, len(rows))
+	dr := 0
+	for i, r := range rows {
+		rc[i] = r * cells
+		dr += r
+	}
+	dest.SetNumRows(dr)
+
+	var err error
+	switch src.DataType() {
+	case etensor.STRING:
+		err = allGatherTensorRowsVString(dest.(*etensor.String), src.(*etensor.String), rc, comm)
+	case etensor.BOOL:
+		dt := dest.(*etensor.Bits)
+		st := src.(*etensor.Bits)
+		sb := make([]uint8, st.Len())
+		for i := range sb {
+			if st.Value1D(i) {
+				sb[i] = 1
+			}
+		}
+		db := make([]uint8, dt.Len())
+		err = comm.AllGathervU8(db, sb, rc, nil)
+		for i, b := range db {
+			dt.Set1D(i, b != 0)
+		}
+	case etensor.UINT8:
+		dt := dest.(*etensor.Uint8)
+		st := src.(*etensor.Uint8)
+		err = comm.AllGathervU8(dt.Values, st.Values, rc, nil)
+	case etensor.INT8:
+		dt := dest.(*etensor.Int8)
+		st := src.(*etensor.Int8)
+		err = comm.AllGathervI8(dt.Values, st.Values, rc, nil)
+	case etensor.UINT16:
+		dt := dest.(*etensor.Uint16)
+		st := src.(*etensor.Uint16)
+		err = comm.AllGathervU16(dt.Values, st.Values, rc, nil)
+	case etensor.INT16:
+		dt := dest.(*etensor.Int16)
+		st := src.(*etensor.Int16)
+		err = comm.AllGathervI16(dt.Values, st.Values, rc, nil)
+	case etensor.UINT32:
+		dt := dest.(*etensor.Uint32)
+		st := src.(*etensor.Uint32)
+		err = comm.AllGathervU32(dt.Values, st.Values, rc, nil)
+	case etensor.INT32:
+		dt := dest.(*etensor.Int32)
+		st := src.(*etensor.Int32)
+		err = comm.AllGathervI32(dt.Values, st.Values, rc, nil)
+	case etensor.UINT64:
+		dt := dest.(*etensor.Uint64)
+		st := src.(*etensor.Uint64)
+		err = comm.AllGathervU64(dt.Values, st.Values, rc, nil)
+	case etensor.INT64:
+		dt := dest.(*etensor.Int64)
+		st := src.(*etensor.Int64)
+		err = comm.AllGathervI64(dt.Values, st.Values, rc, nil)
+	case etensor.INT:
+		dt := dest.(*etensor.Int)
+		st := src.(*etensor.Int)
+		err = comm.AllGathervInt(dt.Values, st.Values, rc, nil)
+	case etensor.FLOAT32:
+		dt := dest.(*etensor.Float32)
+		st := src.(*etensor.Float32)
+		err = comm.AllGathervF32(dt.Values, st.Values, rc, nil)
+	case etensor.FLOAT64:
+		dt := dest.(*etensor.Float64)
+		st := src.(*etensor.Float64)
+		err = comm.AllGathervF64(dt.Values, st.Values, rc, nil)
+	}
+	return err
+}
+
+// allGatherTensorRowsVString does allGatherTensorRowsV for String tensors,
+// given the counts in terms of values (cells).
+// The string lengths are gathered first, followed by the string bytes.
+func allGatherTensorRowsVString(dest, src *etensor.String, rc []int, comm *mpi.Comm) error {
+	sln := make([]int, len(src.Values))
+	dln := make([]int, len(dest.Values))
+	var sdt []byte
+	for i, s := range src.Values {
+		sln[i] = len(s)
+		sdt = append(sdt, s...)
+	}
+	err := comm.AllGathervInt(dln, sln, rc, nil)
+	if err != nil {
+		return err
+	}
+	rbc := make([]int, len(rc)) // byte counts per proc
+	ri, dsz := 0, 0
+	for p := range rc {
+		for _, l := range dln[ri : ri+rc[p]] {
+			rbc[p] += l
+		}
+		ri += rc[p]
+		dsz += rbc[p]
+	}
+	ddt := make([]byte, dsz)
+	err = comm.AllGathervU8(ddt, sdt, rbc, nil)
+	idx := 0
+	for i, l := range dln {
+		dest.Values[i] = string(ddt[idx : idx+l])
+		idx += l
+	}
+	return err
+}
+
+// ScatterTensorRowsV does an MPI Scatterv of given src tensor data on the
+// Root proc, distributing its rows to all procs, using a row-based tensor
+// organization (as in an etable.Table), with counts[r] rows going to proc r,
+// in order, into dest.  src is only used on Root, where it must have the
+// total number of rows in counts.  counts must be the same on all procs.
+// dest must have same cell shape as src, but rows will be enforced.
+// This is the inverse of GatherTensorRowsPadded, for load-balanced
+// partitioning of data, e.g., with uneven numbers of rows per proc.
+func ScatterTensorRowsV(dest, src etensor.Tensor, counts []int, comm *mpi.Comm) error {
+	np := comm.Size()
+	rank := comm.Rank()
+	isRoot := rank == mpi.Root
+	if len(counts) != np {
+		err := fmt.Errorf("empi.ScatterTensorRowsV: len(counts) %d != number of procs: %d", len(counts), np)
+		log.Println(err)
+		return err
+	}
+	_, cells := dest.RowCellSize()
+	// src is only checked on Root, so the result is broadcast so that
+	// the other procs do not block in Scatterv when Root returns early.
+	var err error
+	if isRoot {
+		total := 0
+		for _, c := range counts {
+			total += c
+		}
+		switch {
+		case src == nil || src.DataType() != dest.DataType():
+			err = fmt.Errorf("empi.ScatterTensorRowsV: src must have the same data type as dest: %v", dest.DataType())
+		case src.Len() < total*cells:
+			err = fmt.Errorf("empi.ScatterTensorRowsV: src has %d values, less than total counts %d * cells %d", src.Len(), total, cells)
+		}
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	if np > 1 {
+		bad := []int{0}
+		if err != nil {
+			bad[0] = 1
+		}
+		if berr := comm.BcastInt(mpi.Root, bad); berr != nil {
+			return berr
+		}
+		if bad[0] != 0 && err == nil {
+			err = fmt.Errorf("empi.ScatterTensorRowsV: invalid src on proc %d", mpi.Root)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	dest.SetNumRows(counts[rank])
+	if np == 1 {
+		dest.CopyCellsFrom(src, 0, 0, counts[0]*cells)
+		return nil
+	}
+	sc := make([]int, np)
+	for i, c := range counts {
+		sc[i] = c * cells
+	}
+
+	switch dest.DataType() {
+	case etensor.STRING:
+		var st *etensor.String
+		if isRoot {
+			st = src.(*etensor.String)
+		}
+		err = scatterTensorRowsVString(dest.(*etensor.String), st, sc, comm)
+	case etensor.BOOL:
+		dt := dest.(*etensor.Bits)
+		var sb []uint8
+		if isRoot {
+			st := src.(*etensor.Bits)
+			sb = make([]uint8, st.Len())
+			for i := range sb {
+				if st.Value1D(i) {
+					sb[i] = 1
+				}
+			}
+		}
+		db := make([]uint8, dt.Len())
+		err = comm.ScattervU8(mpi.Root, db, sb, sc, nil)
+		for i, b := range db {
+			dt.Set1D(i, b != 0)
+		}
+	case etensor.UINT8:
+		dt := dest.(*etensor.Uint8)
+		var sv []uint8
+		if isRoot {
+			sv = src.(*etensor.Uint8).Values
+		}
+		err = comm.ScattervU8(mpi.Root, dt.Values, sv, sc, nil)
+	case etensor.INT8:
+		dt := dest.(*etensor.Int8)
+		var sv []int8
+		if isRoot {
+			sv = src.(*etensor.Int8).Values
+		}
+		err = comm.ScattervI8(mpi.Root, dt.Values, sv, sc, nil)
+	case etensor.UINT16:
+		dt := dest.(*etensor.Uint16)
+		var sv []uint16
+		if isRoot {
+			sv = src.(*etensor.Uint16).Values
+		}
+		err = comm.ScattervU16(mpi.Root, dt.Values, sv, sc, nil)
+	case etensor.INT16:
+		dt := dest.(*etensor.Int16)
+		var sv []int16
+		if isRoot {
+			sv = src.(*etensor.Int16).Values
+		}
+		err = comm.ScattervI16(mpi.Root, dt.Values, sv, sc, nil)
+	case etensor.UINT32:
+		dt := dest.(*etensor.Uint32)
+		var sv []uint32
+		if isRoot {
+			sv = src.(*etensor.Uint32).Values
+		}
+		err = comm.ScattervU32(mpi.Root, dt.Values, sv, sc, nil)
+	case etensor.INT32:
+		dt := dest.(*etensor.Int32)
+		var sv []int32
+		if isRoot {
+			sv = src.(*etensor.Int32).Values
+		}
+		err = comm.ScattervI32(mpi.Root, dt.Values, sv, sc, nil)
+	case etensor.UINT64:
+		dt := dest.(*etensor.Uint64)
+		var sv []uint64
+		if isRoot {
+			sv = src.(*etensor.Uint64).Values
+		}
+		err = comm.ScattervU64(mpi.Root, dt.Values, sv, sc, nil)
+	case etensor.INT64:
+		dt := dest.(*etensor.Int64)
+		var sv []int64
+		if isRoot {
+			sv = src.(*etensor.Int64).Values
+		}
+		err = comm.ScattervI64(mpi.Root, dt.Values, sv, sc, nil)
+	case etensor.INT:
+		dt := dest.(*etensor.Int)
+		var sv []int
+		if isRoot {
+			sv = src.(*etensor.Int).Values
+		}
+		err = comm.ScattervInt(mpi.Root, dt.Values, sv, sc, nil)
+	case etensor.FLOAT32:
+		dt := dest.(*etensor.Float32)
+		var sv []float32
+		if isRoot {
+			sv = src.(*etensor.Float32).Values
+		}
+		err = comm.ScattervF32(mpi.Root, dt.Values, sv, sc, nil)
+	case etensor.FLOAT64:
+		dt := dest.(*etensor.Float64)
+		var sv []float64
+		if isRoot {
+			sv = src.(*etensor.Float64).Values
+		}
+		err = comm.ScattervF64(mpi.Root, dt.Values, sv, sc, nil)
+	}
+	return err
+}
+
+// scatterTensorRowsVString does ScatterTensorRowsV for String tensors,
+// given the counts in terms of values (cells).  src is only used on Root.
+// The string lengths are scattered first, followed by the string bytes.
+func scatterTensorRowsVString(dest, src *etensor.String, sc []int, comm *mpi.Comm) error {
+	isRoot := comm.Rank() == mpi.Root
+	var sln []int
+	var sdt []byte
+	sbc := make([]int, len(sc)) // byte counts per proc
+	if isRoot {
+		sln = make([]int, len(src.Values))
+		si := 0
+		for p, c := range sc {
+			for i := si; i < si+c; i++ {
+				s := src.Values[i]
+				sln[i] = len(s)
+				sbc[p] += len(s)
+				sdt = append(sdt, s...)
+			}
+			si += c
+		}
+	}
+	dln := make([]int, len(dest.Values))
+	err := comm.ScattervInt(mpi.Root, dln, sln, sc, nil)
+	if err != nil {
+		return err
+	}
+	dsz := 0
+	for _, l := range dln {
+		dsz += l
+	}
+	ddt := make([]byte, dsz)
+	err = comm.ScattervU8(mpi.Root, ddt, sdt, sbc, nil)
+	idx := 0
+	for i, l := range dln {
+		dest.Values[i] = string(ddt[idx : idx+l])
+		idx += l
+	}
+	return err
+}
+
 // ReduceTensor does an MPI AllReduce on given src tensor data, using given operation,
 // gathering into dest.  dest must have same overall shape as src -- will be enforced.
 // IMPORTANT: src and dest must be different slices!
 // each processor must have the same shape and organization for this to make sense.
 // does nothing for strings.
+// For etensor.Bits (BOOL) tensors, the packed bit bytes are reduced directly,
+// so only the bitwise OpBAND and OpBOR operations are meaningful, and any
+// other op returns an error: use OpBOR to compute logical OR of the bits
+// across processors, and OpBAND for logical AND.
+// etensor.Int (INT) tensors are reduced with AllReduceInt, which uses the MPI
+// datatype matching the size of Go int on the current platform, so this is
+// correct on both 64 and 32 bit platforms, as long as all procs use the same one.
 func ReduceTensor(dest, src etensor.Tensor, comm *mpi.Comm, op mpi.Op) error {
 	dt := src.DataType()
 	if dt == etensor.STRING {
@@ -146,6 +664,11 @@ func ReduceTensor(dest, src etensor.Tensor, comm *mpi.Comm, op mpi.Op) error {
 	var err error
 	switch dt {
 	case etensor.BOOL:
+		if op != mpi.OpBAND && op != mpi.OpBOR {
+			err = fmt.Errorf("empi.ReduceTensor: only OpBAND and OpBOR are supported for etensor.Bits, not: %d", op)
+			log.Println(err)
+			return err
+		}
 		dt := dest.(*etensor.Bits)
 		st := src.(*etensor.Bits)
 		err = comm.AllReduceU8(op, dt.Values, st.Values)
@@ -193,6 +716,134 @@ func ReduceTensor(dest, src etensor.Tensor, comm *mpi.Comm, op mpi.Op) error {
 		dt := dest.(*etensor.Float64)
 		st := src.(*etensor.Float64)
 		err = comm.AllReduceF64(op, dt.Values, st.Values)
+	default:
+		err = fmt.Errorf("empi.ReduceTensor: data type not supported: %v", dt)
+		log.Println(err)
+	}
+	return err
+}
+
+// exchangeTensorRows does an MPI AllToAllv to exchange rows of src tensor data
+// among procs, using a row-based tensor organization (as in an etable.Table).
+// The src rows must be ordered by the proc they are sent to, with sendRows[i]
+// rows going to proc i.  The recvRows[i] rows received from proc i
+// are stored into dest in rank order.  dest must have the same cell shape
+// as src, and its number of rows is set to the total of recvRows.
+func exchangeTensorRows(dest, src etensor.Tensor, sendRows, recvRows []int, comm *mpi.Comm) error {
+	_, cells := src.RowCellSize()
+	sc := make([]int, len(sendRows))
+	rc := make([]int, len(recvRows))
+	dr := 0
+	for i := range sendRows {
+		sc[i] = sendRows[i] * cells
+		rc[i] = recvRows[i] * cells
+		dr += recvRows[i]
+	}
+	dest.SetNumRows(dr)
+
+	var err error
+	switch src.DataType() {
+	case etensor.STRING:
+		err = exchangeTensorRowsString(dest.(*etensor.String), src.(*etensor.String), sc, rc, comm)
+	case etensor.BOOL:
+		dt := dest.(*etensor.Bits)
+		st := src.(*etensor.Bits)
+		sb := make([]uint8, st.Len())
+		for i := range sb {
+			if st.Value1D(i) {
+				sb[i] = 1
+			}
+		}
+		db := make([]uint8, dt.Len())
+		err = comm.AllToAllvU8(db, sb, sc, nil, rc, nil)
+		for i, b := range db {
+			dt.Set1D(i, b != 0)
+		}
+	case etensor.UINT8:
+		dt := dest.(*etensor.Uint8)
+		st := src.(*etensor.Uint8)
+		err = comm.AllToAllvU8(dt.Values, st.Values, sc, nil, rc, nil)
+	case etensor.INT8:
+		dt := dest.(*etensor.Int8)
+		st := src.(*etensor.Int8)
+		err = comm.AllToAllvI8(dt.Values, st.Values, sc, nil, rc, nil)
+	case etensor.UINT16:
+		dt := dest.(*etensor.Uint16)
+		st := src.(*etensor.Uint16)
+		err = comm.AllToAllvU16(dt.Values, st.Values, sc, nil, rc, nil)
+	case etensor.INT16:
+		dt := dest.(*etensor.Int16)
+		st := src.(*etensor.Int16)
+		err = comm.AllToAllvI16(dt.Values, st.Values, sc, nil, rc, nil)
+	case etensor.UINT32:
+		dt := dest.(*etensor.Uint32)
+		st := src.(*etensor.Uint32)
+		err = comm.AllToAllvU32(dt.Values, st.Values, sc, nil, rc, nil)
+	case etensor.INT32:
+		dt := dest.(*etensor.Int32)
+		st := src.(*etensor.Int32)
+		err = comm.AllToAllvI32(dt.Values, st.Values, sc, nil, rc, nil)
+	case etensor.UINT64:
+		dt := dest.(*etensor.Uint64)
+		st := src.(*etensor.Uint64)
+		err = comm.AllToAllvU64(dt.Values, st.Values, sc, nil, rc, nil)
+	case etensor.INT64:
+		dt := dest.(*etensor.Int64)
+		st := src.(*etensor.Int64)
+		err = comm.AllToAllvI64(dt.Values, st.Values, sc, nil, rc, nil)
+	case etensor.INT:
+		dt := dest.(*etensor.Int)
+		st := src.(*etensor.Int)
+		err = comm.AllToAllvInt(dt.Values, st.Values, sc, nil, rc, nil)
+	case etensor.FLOAT32:
+		dt := dest.(*etensor.Float32)
+		st := src.(*etensor.Float32)
+		err = comm.AllToAllvF32(dt.Values, st.Values, sc, nil, rc, nil)
+	case etensor.FLOAT64:
+		dt := dest.(*etensor.Float64)
+		st := src.(*etensor.Float64)
+		err = comm.AllToAllvF64(dt.Values, st.Values, sc, nil, rc, nil)
+	}
+	return err
+}
+
+// exchangeTensorRowsString does exchangeTensorRows for String tensors,
+// given the send and receive counts in terms of values (cells).
+// The string lengths are exchanged first, followed by the string bytes.
+func exchangeTensorRowsString(dest, src *etensor.String, sc, rc []int, comm *mpi.Comm) error {
+	sln := make([]int, len(src.Values))
+	dln := make([]int, len(dest.Values))
+	for i, s := range src.Values {
+		sln[i] = len(s)
+	}
+	err := comm.AllToAllvInt(dln, sln, sc, nil, rc, nil)
+	if err != nil {
+		return err
+	}
+	sbc := make([]int, len(sc)) // byte counts per proc
+	rbc := make([]int, len(rc))
+	si, ri, dsz := 0, 0, 0
+	for p := range sc {
+		for _, l := range sln[si : si+sc[p]] {
+			sbc[p] += l
+		}
+		for _, l := range dln[ri : ri+rc[p]] {
+			rbc[p] += l
+		}
+		si += sc[p]
+		ri += rc[p]
+		dsz += rbc[p]
+	}
+	var sdt []byte
+	for _, s := range src.Values[:si] {
+		sdt = append(sdt, s...)
+	}
+	ddt := make([]byte, dsz)
+	err = comm.AllToAllvU8(ddt, sdt, sbc, nil, rbc, nil)
+	idx := 0
+	for i, l := range dln {
+		dest.Values[i] = string(ddt[idx : idx+l])
+		idx += l
 	}
 	return err
 }
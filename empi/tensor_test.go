@@ -0,0 +1,44 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package empi
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/emer/etable/v2/etensor"
+)
+
+func TestGatherTensorRows(t *testing.T) {
+	types := []etensor.Type{etensor.BOOL, etensor.UINT8, etensor.INT8, etensor.UINT16, etensor.INT16,
+		etensor.UINT32, etensor.INT32, etensor.UINT64, etensor.INT64, etensor.INT,
+		etensor.FLOAT32, etensor.FLOAT64, etensor.STRING}
+	comm := &LocalComm{}
+	for _, dt := range types {
+		// 9 cells, so Bits are not a multiple of 8
+		src := etensor.New(dt, []int{3, 3}, nil, nil)
+		for i := 0; i < src.Len(); i++ {
+			if dt == etensor.STRING {
+				src.SetString1D(i, "s"+strconv.Itoa(i))
+			} else {
+				src.SetFloat1D(i, float64(i%4))
+			}
+		}
+		dest := etensor.New(dt, []int{1, 3}, nil, nil)
+		if err := GatherTensorRows(dest, src, comm); err != nil {
+			t.Errorf("%v: %v", dt, err)
+			continue
+		}
+		if dest.Len() != src.Len() {
+			t.Errorf("%v: dest.Len() %d != src.Len() %d", dt, dest.Len(), src.Len())
+			continue
+		}
+		for i := 0; i < src.Len(); i++ {
+			if dv, sv := dest.StringVal1D(i), src.StringVal1D(i); dv != sv {
+				t.Errorf("%v: index %d: got %s, want %s", dt, i, dv, sv)
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+
+package mpi
+
+/*
+#cgo pkg-config: ompi
+#include "mpi.h"
+*/
+import "C"
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// LibraryVersion returns the version string of the MPI library
+// that is being used at run time, e.g., "Open MPI v4.1.2, ...".
+func LibraryVersion() string {
+	var buf [C.MPI_MAX_LIBRARY_VERSION_STRING]C.char
+	var n C.int
+	C.MPI_Get_library_version(&buf[0], &n)
+	return C.GoStringN(&buf[0], n)
+}
+
+// CheckABI checks that the MPI library used at run time matches the version
+// of the MPI headers that this package was compiled against, returning an
+// error, and logging a loud warning, if not.  A mismatch, e.g., from building
+// against one OpenMPI version and running with another, can cause subtle
+// data corruption instead of a clean failure.  Only the major and minor
+// library versions are compared, as patch releases are ABI compatible.
+// This is called by Init and InitThreadSafe if CheckABIAtInit is set.
+func CheckABI() error {
+	var ver, sub C.int
+	C.MPI_Get_version(&ver, &sub)
+	var errs []string
+	if ver != C.MPI_VERSION || sub != C.MPI_SUBVERSION {
+		errs = append(errs, fmt.Sprintf("MPI standard version: compiled: %d.%d, run time: %d.%d", C.MPI_VERSION, C.MPI_SUBVERSION, ver, sub))
+	}
+	lib := LibraryVersion()
+	cmaj, cmin := int(C.OMPI_MAJOR_VERSION), int(C.OMPI_MINOR_VERSION)
+	cver := fmt.Sprintf("Open MPI v%d.%d.%d", cmaj, cmin, C.OMPI_RELEASE_VERSION)
+	if maj, mnr, ok := parseLibraryVersion(lib); !ok || maj != cmaj || mnr != cmin {
+		errs = append(errs, fmt.Sprintf("library: compiled: %s, run time: %s", cver, strings.SplitN(lib, ",", 2)[0]))
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	err := fmt.Errorf("mpi.CheckABI: MPI headers and run time library do not match, results may be corrupted: %s", strings.Join(errs, "; "))
+	log.Println("\n***** WARNING *****\n", err)
+	return err
+}
+
+// parseLibraryVersion returns the major and minor version numbers from
+// an Open MPI library version string, e.g., 5 and 0 for "Open MPI v5.0.10, ...",
+// with ok false if it is not in that form.
+func parseLibraryVersion(lib string) (major, minor int, ok bool) {
+	v, found := strings.CutPrefix(lib, "Open MPI v")
+	if !found {
+		return
+	}
+	mj, rest, found := strings.Cut(v, ".")
+	if !found {
+		return
+	}
+	// the minor version can be followed by ".patch", or a suffix such as "rc1"
+	if i := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		rest = rest[:i]
+	}
+	var err error
+	if major, err = strconv.Atoi(mj); err != nil {
+		return 0, 0, false
+	}
+	if minor, err = strconv.Atoi(rest); err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
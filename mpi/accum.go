@@ -0,0 +1,31 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// ReduceAccumF32 reduces all values across procs to toProc from orig
+// using given operation, like ReduceF32, except that the reduced result
+// is combined into the existing contents of dest using the same operation
+// (e.g., added to it for OpSum), instead of overwriting it, which supports
+// accumulating results across multiple calls.  dest is only used on toProc,
+// and is not touched on other procs.
+// IMPORTANT: orig and dest must be different slices, of the same length on toProc.
+func (cm *Comm) ReduceAccumF32(toProc int, op Op, dest, orig []float32) error {
+	if cm.Size() == 1 {
+		return ReduceLocalF32(op, dest, orig)
+	}
+	if cm.Rank() != toProc {
+		return cm.ReduceF32(toProc, op, nil, orig)
+	}
+	// reduce before checking dest, so the other procs are not left blocking
+	tmp := make([]float32, len(orig))
+	err := cm.ReduceF32(toProc, op, tmp, orig)
+	if err != nil {
+		return err
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.ReduceAccumF32: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	return ReduceLocalF32(op, dest, tmp)
+}
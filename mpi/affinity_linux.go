@@ -0,0 +1,37 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build linux
+
+package mpi
+
+import (
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// SetProgressAffinity locks the calling goroutine to its current OS thread
+// and restricts that thread to run only on the given CPU, so that MPI
+// communication done from this goroutine (e.g., a dedicated goroutine that
+// drives non-blocking operations to completion with Test and Wait) does not
+// contend with compute threads.  It must be called from the goroutine that
+// does the communication, which then remains locked to its OS thread.
+// This is only supported on Linux (using sched_setaffinity), and returns
+// an error on other platforms.
+func SetProgressAffinity(cpu int) error {
+	const maskWords = 16 // 1024 CPUs, as in the default glibc cpu_set_t
+	if cpu < 0 || cpu >= maskWords*64 {
+		return errorf("mpi.SetProgressAffinity: cpu out of range: %d", cpu)
+	}
+	var mask [maskWords]uint64
+	mask[cpu/64] = 1 << (cpu % 64)
+	runtime.LockOSThread()
+	_, _, en := syscall.RawSyscall(syscall.SYS_SCHED_SETAFFINITY, 0, uintptr(len(mask)*8), uintptr(unsafe.Pointer(&mask[0])))
+	if en != 0 {
+		runtime.UnlockOSThread()
+		return errorf("mpi.SetProgressAffinity: sched_setaffinity for cpu %d failed: %v", cpu, en)
+	}
+	return nil
+}
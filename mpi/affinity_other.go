@@ -0,0 +1,14 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package mpi
+
+// SetProgressAffinity locks the calling goroutine to its current OS thread
+// and restricts that thread to run only on the given CPU.
+// This is only supported on Linux, and returns an error on other platforms.
+func SetProgressAffinity(cpu int) error {
+	return errorf("mpi.SetProgressAffinity: CPU affinity is only supported on Linux")
+}
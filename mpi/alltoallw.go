@@ -0,0 +1,70 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+
+package mpi
+
+/*
+#cgo pkg-config: ompi
+#include "mpi.h"
+
+extern MPI_Datatype BYTE;
+*/
+import "C"
+
+import "unsafe"
+
+// AllToAllw sends a separate buffer of bytes to each proc, sendBufs[i] going
+// to proc i, and receives a separate buffer from each proc into recvBufs,
+// with recvBufs[i] coming from proc i, using MPI_Alltoallw.
+// This is the most general all-to-all exchange: each buffer can be of a
+// different size, and can hold a different type of data (e.g., int values
+// for one proc and float32 values for another, as encoded by the caller),
+// to implement arbitrary data shuffles.  For the common case of the same
+// type of data, use the typed AllToAllv methods instead.
+// Both must have one buffer per proc, and len(recvBufs[i]) must equal
+// len(sendBufs[rank]) on proc i.
+func (cm *Comm) AllToAllw(sendBufs, recvBufs [][]byte) error {
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(sendBufs) != np || len(recvBufs) != np {
+		return errorf("mpi.AllToAllw: len(sendBufs) %d and len(recvBufs) %d must equal number of procs: %d", len(sendBufs), len(recvBufs), np)
+	}
+	sc := make([]int, np)
+	rc := make([]int, np)
+	for i := range sc {
+		sc[i] = len(sendBufs[i])
+		rc[i] = len(recvBufs[i])
+	}
+	sd, stot := Displacements(sc)
+	cm.countMetric("AllToAllw", stot)
+	rd, rtot := Displacements(rc)
+	// MPI displacements are relative to a single buffer, so buffers are packed
+	var sdt []byte
+	for _, b := range sendBufs {
+		sdt = append(sdt, b...)
+	}
+	rdt := make([]byte, rtot)
+	var sendbuf, recvbuf unsafe.Pointer
+	if len(sdt) > 0 {
+		sendbuf = unsafe.Pointer(&sdt[0])
+	}
+	if len(rdt) > 0 {
+		recvbuf = unsafe.Pointer(&rdt[0])
+	}
+	types := make([]C.MPI_Datatype, np)
+	for i := range types {
+		types[i] = C.BYTE
+	}
+	csc, csd, crc, crd := cInts(sc), cInts(sd), cInts(rc), cInts(rd)
+	err := Error(C.MPI_Alltoallw(sendbuf, &csc[0], &csd[0], &types[0], recvbuf, &crc[0], &crd[0], &types[0], cm.comm), "AllToAllw")
+	if err != nil {
+		return err
+	}
+	for i, b := range recvBufs {
+		copy(b, rdt[rd[i]:])
+	}
+	return nil
+}
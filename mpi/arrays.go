@@ -0,0 +1,136 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// SendArrays sends vals to toProc, using given unique tag identifier,
+// where T is a fixed-size type without any pointers, such as an array
+// or struct of numbers (e.g., [4]float32).  The contiguous memory of vals
+// is sent directly as bytes, avoiding the need to flatten the values into a
+// slice of numbers.  Returns an error if T contains any pointers (including
+// slices, strings, maps, and interfaces), as they are not meaningful on other procs.
+// This is Blocking. Must have a corresponding RecvArrays call with same tag on toProc, from this proc
+func SendArrays[T any](cm *Comm, toProc, tag int, vals []T) error {
+	buf, err := arrayBytes(vals, "SendArrays")
+	if err != nil || len(buf) == 0 {
+		return err
+	}
+	return cm.SendU8(toProc, tag, buf)
+}
+
+// RecvArrays receives vals from proc fmProc (which can be AnySource),
+// using given unique tag identifier, where T is a fixed-size type without
+// any pointers, as sent by SendArrays.  vals must have the same length
+// as the slice sent.
+// This is Blocking. Must have a corresponding SendArrays call with same tag on fmProc, to this proc
+func RecvArrays[T any](cm *Comm, fmProc, tag int, vals []T) error {
+	buf, err := arrayBytes(vals, "RecvArrays")
+	if err != nil || len(buf) == 0 {
+		return err
+	}
+	return cm.RecvU8(fmProc, tag, buf)
+}
+
+// arrayBytes returns the memory of vals as a slice of bytes,
+// or an error if T contains pointers.
+func arrayBytes[T any](vals []T, ctxt string) ([]byte, error) {
+	typ := reflect.TypeFor[T]()
+	if hasPointers(typ) {
+		return nil, errorf("mpi.%s: type %s contains pointers, so it cannot be transferred as bytes", ctxt, typ)
+	}
+	if len(vals) == 0 {
+		return nil, nil
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(&vals[0])), len(vals)*int(typ.Size())), nil
+}
+
+// hasPointers returns true if given type is or contains any pointers.
+func hasPointers(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Array:
+		return typ.Len() > 0 && hasPointers(typ.Elem())
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if hasPointers(typ.Field(i).Type) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Numeric is the set of numeric element types supported by the typed methods.
+type Numeric interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64 | ~complex64 | ~complex128
+}
+
+// RootComputeBcast calls compute only on the Root proc, and broadcasts the
+// resulting values to all other procs, returning them on all procs,
+// e.g., for reading a file or drawing shared random numbers on Root.
+// The number of values is broadcast first, so the other procs do not need
+// to know it in advance, and can have a different result from compute.
+func RootComputeBcast[T Numeric](cm *Comm, compute func() []T) ([]T, error) {
+	isRoot := cm.Rank() == Root
+	var vals []T
+	if isRoot {
+		vals = compute()
+	}
+	if cm.Size() == 1 {
+		return vals, nil
+	}
+	n := []int{len(vals)}
+	err := cm.BcastInt(Root, n)
+	if err != nil {
+		return nil, err
+	}
+	if !isRoot {
+		vals = make([]T, n[0])
+	}
+	buf, err := arrayBytes(vals, "RootComputeBcast")
+	if err != nil || len(buf) == 0 {
+		return vals, err
+	}
+	return vals, cm.BcastU8(Root, buf)
+}
+
+// BcastShape broadcasts the tensor shape (the size of each dimension)
+// pointed to by shape from proc fmProc to all other procs, where the shape
+// slice is resized to the number of dimensions and set to the sizes,
+// so that a tensor can be allocated to receive data before a Bcast.
+// The number of dimensions is broadcast first, followed by the sizes,
+// if there are any: a zero-dimensional shape results in an empty slice.
+// It must be called on all procs.
+func (cm *Comm) BcastShape(fmProc int, shape *[]int) error {
+	if cm.Size() == 1 {
+		return nil
+	}
+	isFrom := cm.Rank() == fmProc
+	nd := []int{0}
+	if isFrom {
+		nd[0] = len(*shape)
+	}
+	err := cm.BcastInt(fmProc, nd)
+	if err != nil {
+		return err
+	}
+	if !isFrom {
+		*shape = make([]int, nd[0])
+	}
+	if nd[0] == 0 {
+		return nil
+	}
+	return cm.BcastInt(fmProc, *shape)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBcastShape(t *testing.T) {
+	cm, err := NewComm(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name  string
+		shape []int
+	}{
+		{"scalar nil", nil},
+		{"scalar", []int{}},
+		{"empty 1d", []int{0}},
+		{"empty 2d", []int{3, 0}},
+		{"3d", []int{2, 3, 4}},
+	}
+	for _, tt := range tests {
+		for fm := 0; fm < cm.Size(); fm++ {
+			shape := []int{7, 7} // must be replaced on other procs
+			if cm.Rank() == fm {
+				shape = slices.Clone(tt.shape)
+			}
+			if err := cm.BcastShape(fm, &shape); err != nil {
+				t.Fatalf("%s: %v", tt.name, err)
+			}
+			if !slices.Equal(shape, tt.shape) {
+				t.Errorf("%s: proc %d from %d: got %v, want %v", tt.name, cm.Rank(), fm, shape, tt.shape)
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// AssertSameInt checks that all procs have the same value v, e.g.,
+// for a shared random seed or config parameter that must be identical on
+// all procs, returning an error on all procs, reporting the range of values,
+// if they differ.  It all-reduces the min and max of v, so it must be called
+// on all procs.
+func (cm *Comm) AssertSameInt(v int) error {
+	if cm.Size() == 1 {
+		return nil
+	}
+	mn := []int{0}
+	mx := []int{0}
+	if err := cm.AllReduceInt(OpMin, mn, []int{v}); err != nil {
+		return err
+	}
+	if err := cm.AllReduceInt(OpMax, mx, []int{v}); err != nil {
+		return err
+	}
+	if mn[0] != mx[0] {
+		return errorf("mpi.AssertSameInt: procs disagree on value: min %d != max %d (this proc: %d: %d)", mn[0], mx[0], cm.Rank(), v)
+	}
+	return nil
+}
+
+// AssertSameF64 checks that all procs have the same value v, e.g.,
+// for a shared config parameter that must be identical on all procs,
+// returning an error on all procs, reporting the range of values,
+// if they differ.  It all-reduces the min and max of v, so it must be called
+// on all procs.  Values must be exactly equal.
+func (cm *Comm) AssertSameF64(v float64) error {
+	if cm.Size() == 1 {
+		return nil
+	}
+	mn := []float64{0}
+	mx := []float64{0}
+	if err := cm.AllReduceF64(OpMin, mn, []float64{v}); err != nil {
+		return err
+	}
+	if err := cm.AllReduceF64(OpMax, mx, []float64{v}); err != nil {
+		return err
+	}
+	if mn[0] != mx[0] {
+		return errorf("mpi.AssertSameF64: procs disagree on value: min %g != max %g (this proc: %d: %g)", mn[0], mx[0], cm.Rank(), v)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// BarrierTimed does a Barrier, measuring how long this proc waited in it
+// (localWait, in seconds, using Wtime), and all-reduces the wait times to
+// return the maximum and minimum wait across all procs.
+// The proc that arrives last waits the least, so a large spread between
+// maxWait and minWait indicates load imbalance in the preceding work,
+// with maxWait being the time wasted by the fastest proc.
+func (cm *Comm) BarrierTimed() (localWait, maxWait, minWait float64, err error) {
+	st := Wtime()
+	err = cm.Barrier()
+	if err != nil {
+		return
+	}
+	localWait = Wtime() - st
+	if cm.Size() == 1 {
+		return localWait, localWait, localWait, nil
+	}
+	// negated min allows a single Max reduce
+	wt := []float64{localWait, -localWait}
+	ext := []float64{0, 0}
+	err = cm.AllReduceF64(OpMax, ext, wt)
+	maxWait, minWait = ext[0], -ext[1]
+	return
+}
+
+// BarrierStatus synchronizes all procs like Barrier, while also exchanging
+// a status flag, returning allOK = true on all procs only if localOK is true
+// on every proc, e.g., so that all procs can learn at a phase boundary
+// whether any proc hit an error, and shut down cleanly together.
+// This is done with a single logical AND AllReduce, which no proc can
+// complete until all procs have called it.
+func (cm *Comm) BarrierStatus(localOK bool) (allOK bool, err error) {
+	if cm.Size() == 1 {
+		return localOK, nil
+	}
+	ok := []int32{0}
+	if localOK {
+		ok[0] = 1
+	}
+	all := []int32{0}
+	err = cm.AllReduceI32(OpLAND, all, ok)
+	return all[0] != 0, err
+}
+
+// barrierDiagTag is the message tag used for the arrival and release
+// tokens in BarrierDiag, on its private communicator.
+const barrierDiagTag = 7300
+
+// barrierDiagState holds the state for BarrierDiag.
+type barrierDiagState struct {
+	// comm is a private duplicate of the communicator, so that the
+	// tokens do not interfere with other messages, even when left
+	// pending after a timeout.
+	comm *Comm
+
+	// pending are the requests left pending by a BarrierDiag
+	// that timed out, which are retained until they complete.
+	pending []*Request
+}
+
+// BarrierDiag synchronizes all procs like Barrier, but with diagnostics
+// for when some procs never arrive, which is the most common form of hang.
+// Each proc sends an arrival token to the Root proc, which releases all
+// procs once every token has been received, all using non-blocking
+// point-to-point messages on a private duplicate of the communicator,
+// which is created on the first call.  If this does not complete within timeout,
+// the Root proc logs the ranks that never arrived, e.g.,
+// "ranks [7 13] never arrived", and the other procs log that they
+// were not released, and an error is returned.  After a timeout,
+// the messages of this call are left pending, and any further
+// BarrierDiag call on this Comm returns an error, so the job should
+// typically be aborted at that point.
+func (cm *Comm) BarrierDiag(timeout time.Duration) error {
+	np := cm.Size()
+	if np == 1 {
+		return nil
+	}
+	bs := cm.barrierDiag
+	if bs == nil {
+		dc, err := cm.dup()
+		if err != nil {
+			return err
+		}
+		bs = &barrierDiagState{comm: dc}
+		cm.barrierDiag = bs
+	}
+	if len(bs.pending) > 0 {
+		return errorf("mpi.BarrierDiag: a previous BarrierDiag timed out on proc %d", cm.Rank())
+	}
+	if cm.IsRoot() {
+		return bs.root(timeout)
+	}
+	dc := bs.comm
+	tok := []int32{int32(cm.Rank())}
+	rel := []int32{0}
+	sreq, err := dc.IsendI32(Root, barrierDiagTag, tok)
+	if err != nil {
+		return err
+	}
+	rreq, err := dc.IrecvI32(Root, barrierDiagTag, rel)
+	if err != nil {
+		return err
+	}
+	reqs := []*Request{sreq, rreq}
+	pending, err := barrierDiagPoll(reqs, timeout)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	bs.pending = reqs
+	err = fmt.Errorf("mpi.BarrierDiag: proc %d not released by root after %v: some proc never arrived (see root log)", cm.Rank(), timeout)
+	log.Println(err)
+	return err
+}
+
+// root is the Root proc side of BarrierDiag.
+func (bs *barrierDiagState) root(timeout time.Duration) error {
+	dc := bs.comm
+	np := dc.Size()
+	toks := make([]int32, np)
+	reqs := make([]*Request, np-1)
+	for r := 1; r < np; r++ {
+		req, err := dc.IrecvI32(r, barrierDiagTag, toks[r:r+1])
+		if err != nil {
+			return err
+		}
+		reqs[r-1] = req
+	}
+	pending, err := barrierDiagPoll(reqs, timeout)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		bs.pending = reqs
+		missing := make([]int, len(pending))
+		for i, pi := range pending {
+			missing[i] = pi + 1
+		}
+		err = fmt.Errorf("mpi.BarrierDiag: ranks %v never arrived after %v (%d of %d procs arrived)", missing, timeout, np-len(missing), np)
+		log.Println(err)
+		return err
+	}
+	rel := []int32{1}
+	for r := 1; r < np; r++ {
+		req, err := dc.IsendI32(r, barrierDiagTag, rel)
+		if err != nil {
+			return err
+		}
+		reqs[r-1] = req
+	}
+	for _, req := range reqs {
+		if err := req.Wait(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// barrierDiagPoll tests given requests until they are all complete,
+// or timeout has elapsed, returning the indexes of those still pending.
+func barrierDiagPoll(reqs []*Request, timeout time.Duration) ([]int, error) {
+	done := make([]bool, len(reqs))
+	start := time.Now()
+	for {
+		var pending []int
+		for i, req := range reqs {
+			if done[i] {
+				continue
+			}
+			ok, err := req.Test()
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				done[i] = true
+			} else {
+				pending = append(pending, i)
+			}
+		}
+		if len(pending) == 0 || time.Since(start) > timeout {
+			return pending, nil
+		}
+		time.Sleep(50 * time.Microsecond)
+	}
+}
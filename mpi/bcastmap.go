@@ -0,0 +1,69 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import (
+	"encoding/binary"
+	"sort"
+)
+
+// BcastStringMap broadcasts the map of string keys and values pointed to by m
+// from proc fmProc to all other procs, replacing the map on the other procs,
+// e.g., so that config settings parsed on one proc are shared by all.
+// The map is serialized on fmProc with each key and value as a length-prefixed
+// string, in sorted key order, and the bytes are then broadcast.
+// A nil map on fmProc results in an empty (non-nil) map on the other procs.
+func (cm *Comm) BcastStringMap(fmProc int, m *map[string]string) error {
+	if cm.Size() == 1 {
+		return nil
+	}
+	var buf []byte
+	if cm.Rank() == fmProc {
+		keys := make([]string, 0, len(*m))
+		for k := range *m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			buf = binary.AppendUvarint(buf, uint64(len(k)))
+			buf = append(buf, k...)
+			v := (*m)[k]
+			buf = binary.AppendUvarint(buf, uint64(len(v)))
+			buf = append(buf, v...)
+		}
+	}
+	sz := []int{len(buf)}
+	err := cm.BcastInt(fmProc, sz)
+	if err != nil {
+		return err
+	}
+	if cm.Rank() != fmProc {
+		buf = make([]byte, sz[0])
+	}
+	if sz[0] > 0 {
+		err = cm.BcastU8(fmProc, buf)
+		if err != nil {
+			return err
+		}
+	}
+	if cm.Rank() == fmProc {
+		return nil
+	}
+	nm := make(map[string]string)
+	var kv [2]string
+	for len(buf) > 0 {
+		for i := range kv {
+			l, n := binary.Uvarint(buf)
+			if n <= 0 || uint64(len(buf)-n) < l {
+				return errorf("mpi.BcastStringMap: invalid serialized map data")
+			}
+			kv[i] = string(buf[n : n+int(l)])
+			buf = buf[n+int(l):]
+		}
+		nm[kv[0]] = kv[1]
+	}
+	*m = nm
+	return nil
+}
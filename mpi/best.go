@@ -0,0 +1,50 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import "math"
+
+// BestTracker tracks the best (maximum) value across all procs and all
+// steps, e.g., the best validation accuracy so far for early stopping,
+// such that all procs agree on the best value and on whether each step
+// improved it.  Use NewBestTracker to create.
+type BestTracker struct {
+
+	// Comm is the communicator over which values are reduced.
+	Comm *Comm
+
+	// Best is the best value across all procs and steps so far,
+	// which is -Inf before any values have been seen.
+	Best float64
+}
+
+// NewBestTracker returns a new BestTracker for given communicator,
+// with no best value yet.
+func NewBestTracker(cm *Comm) *BestTracker {
+	return &BestTracker{Comm: cm, Best: math.Inf(-1)}
+}
+
+// Update all-reduces the local value for this step with OpMax across
+// all procs, and updates the Best value if the resulting maximum is greater,
+// returning the new global best, and whether this step improved it,
+// consistently on all procs.  NaN values are ignored.
+// It must be called on all procs at the same step.
+func (bt *BestTracker) Update(local float64) (globalBest float64, improved bool, err error) {
+	if math.IsNaN(local) {
+		local = math.Inf(-1)
+	}
+	mx := []float64{local}
+	if bt.Comm.Size() > 1 {
+		err = bt.Comm.AllReduceF64(OpMax, mx, []float64{local})
+		if err != nil {
+			return bt.Best, false, err
+		}
+	}
+	if mx[0] > bt.Best {
+		bt.Best = mx[0]
+		improved = true
+	}
+	return bt.Best, improved, nil
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// GatherCheckpoint gathers each proc's serialized checkpoint state (local)
+// to the Root proc, returning the combined bytes in rank order, along with
+// offsets of length np+1 such that the state of proc r is
+// combined[offsets[r]:offsets[r+1]].  The offsets are needed to restore
+// each proc's state using ScatterCheckpoint.
+// combined and offsets are only returned on Root, and are nil on other procs.
+// Each proc can have a different amount of local state.
+func (cm *Comm) GatherCheckpoint(local []byte) (combined []byte, offsets []int, err error) {
+	np := cm.Size()
+	if np == 1 {
+		combined = make([]byte, len(local))
+		copy(combined, local)
+		return combined, []int{0, len(local)}, nil
+	}
+	lens := make([]int, np)
+	err = cm.AllGatherInt(lens, []int{len(local)})
+	if err != nil {
+		return
+	}
+	isRoot := cm.Rank() == Root
+	if isRoot {
+		offsets = make([]int, np+1)
+		for i, l := range lens {
+			offsets[i+1] = offsets[i] + l
+		}
+		combined = make([]byte, offsets[np])
+	}
+	mxlen := 0
+	for _, l := range lens {
+		mxlen = max(mxlen, l)
+	}
+	if mxlen == 0 {
+		return // nothing to transfer
+	}
+	// pad to max length so all procs send the same amount
+	sdt := make([]byte, mxlen)
+	copy(sdt, local)
+	var ddt []byte
+	if isRoot {
+		ddt = make([]byte, np*mxlen)
+	}
+	err = cm.GatherU8(Root, ddt, sdt)
+	if err != nil || !isRoot {
+		return
+	}
+	for i := range lens {
+		copy(combined[offsets[i]:offsets[i+1]], ddt[i*mxlen:])
+	}
+	return
+}
+
+// ScatterCheckpoint redistributes checkpoint state from the Root proc to all procs,
+// using the combined bytes and offsets as returned by GatherCheckpoint,
+// returning the state for this proc.  combined and offsets are only used on Root,
+// and can be nil on other procs.  offsets must be of length np+1 for the current
+// number of procs, non-decreasing from 0, and within combined: otherwise an error
+// is returned on all procs.
+func (cm *Comm) ScatterCheckpoint(combined []byte, offsets []int) ([]byte, error) {
+	np := cm.Size()
+	isRoot := cm.Rank() == Root
+	lens := make([]int, np)
+	if isRoot {
+		if validOffsets(offsets, np, len(combined)) {
+			for i := range lens {
+				lens[i] = offsets[i+1] - offsets[i]
+			}
+		} else {
+			lens[0] = -1 // tells all procs that offsets are invalid
+		}
+	}
+	if np > 1 {
+		err := cm.BcastInt(Root, lens)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if lens[0] < 0 {
+		return nil, errorf("mpi.ScatterCheckpoint: offsets are not valid for number of procs: %d", np)
+	}
+	mxlen := 0
+	for _, l := range lens {
+		mxlen = max(mxlen, l)
+	}
+	local := make([]byte, mxlen)
+	if np == 1 {
+		copy(local, combined[offsets[0]:offsets[1]])
+		return local, nil
+	}
+	if mxlen == 0 {
+		return local, nil
+	}
+	// pad to max length so all procs receive the same amount
+	var sdt []byte
+	if isRoot {
+		sdt = make([]byte, np*mxlen)
+		for i := range lens {
+			copy(sdt[i*mxlen:], combined[offsets[i]:offsets[i+1]])
+		}
+	}
+	err := cm.ScatterU8(Root, local, sdt)
+	return local[:lens[cm.Rank()]], err
+}
+
+// validOffsets returns true if given offsets, as used in ScatterCheckpoint,
+// are of length np+1, and are non-decreasing from 0 up to at most n.
+func validOffsets(offsets []int, np, n int) bool {
+	if len(offsets) != np+1 || offsets[0] < 0 || offsets[np] > n {
+		return false
+	}
+	for i := 0; i < np; i++ {
+		if offsets[i] > offsets[i+1] {
+			return false
+		}
+	}
+	return true
+}
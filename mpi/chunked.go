@@ -0,0 +1,44 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// AllGatherF32Chunked does AllGatherF32 in chunks of at most chunk values
+// per proc, each gathered with a separate MPI call into a temporary buffer
+// of np * chunk values, and then copied into place in dest, which is tiled
+// by proc with size np * len(orig), as in AllGatherF32.  This keeps the
+// counts passed to MPI well below the int32 limit, and bounds the
+// temporary memory used by MPI, for very large per-proc contributions.
+// len(orig) must be the same on all procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllGatherF32Chunked(dest, orig []float32, chunk int) error {
+	np := cm.Size()
+	n := len(orig)
+	if chunk <= 0 {
+		return errorf("mpi.AllGatherF32Chunked: chunk size must be > 0: %d", chunk)
+	}
+	if len(dest) != np*n {
+		return errorf("mpi.AllGatherF32Chunked: len(dest) %d != number of procs %d * len(orig) %d", len(dest), np, n)
+	}
+	if n == 0 {
+		return nil
+	}
+	if np == 1 {
+		copy(dest, orig)
+		return nil
+	}
+	chunk = min(chunk, n)
+	tmp := make([]float32, np*chunk)
+	for off := 0; off < n; off += chunk {
+		c := min(chunk, n-off)
+		tc := tmp[:np*c]
+		if err := cm.AllGatherF32(tc, orig[off:off+c]); err != nil {
+			return err
+		}
+		for p := 0; p < np; p++ {
+			copy(dest[p*n+off:p*n+off+c], tc[p*c:(p+1)*c])
+		}
+	}
+	return nil
+}
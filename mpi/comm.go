@@ -0,0 +1,18 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// IsRoot returns true if this proc is the Root proc (rank 0) in this Comm.
+func (cm *Comm) IsRoot() bool {
+	return cm.Rank() == Root
+}
+
+// OnRoot calls given function only on the Root proc in this Comm,
+// e.g., for logging or saving results after a Gather.
+func (cm *Comm) OnRoot(fn func()) {
+	if cm.IsRoot() {
+		fn()
+	}
+}
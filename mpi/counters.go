@@ -0,0 +1,74 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import (
+	"encoding/binary"
+	"maps"
+	"sort"
+)
+
+// AllReduceCounters sums the named counters in local across all procs,
+// e.g., for monitoring the global number of errors, retries or batches,
+// returning the global sums on all procs.  Procs can have different sets
+// of names: the names from all procs are first exchanged, so that the
+// result has the union of all names, with missing counters counting as 0.
+// The counters are then summed with a single AllReduce over the values
+// in sorted name order.
+func (cm *Comm) AllReduceCounters(local map[string]int) (map[string]int, error) {
+	np := cm.Size()
+	if np == 1 {
+		return maps.Clone(local), nil
+	}
+	var buf []byte
+	for k := range local {
+		buf = binary.AppendUvarint(buf, uint64(len(k)))
+		buf = append(buf, k...)
+	}
+	counts := make([]int, np)
+	err := cm.AllGatherInt(counts, []int{len(buf)})
+	if err != nil {
+		return nil, err
+	}
+	displs, total := Displacements(counts)
+	all := make([]byte, total)
+	if total > 0 {
+		err = cm.AllGathervU8(all, buf, counts, displs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	names := make(map[string]bool)
+	for len(all) > 0 {
+		l, n := binary.Uvarint(all)
+		if n <= 0 || uint64(len(all)-n) < l {
+			return nil, errorf("mpi.AllReduceCounters: invalid serialized names data")
+		}
+		names[string(all[n:n+int(l)])] = true
+		all = all[n+int(l):]
+	}
+	if len(names) == 0 {
+		return map[string]int{}, nil
+	}
+	keys := make([]string, 0, len(names))
+	for k := range names {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	vals := make([]int, len(keys))
+	for i, k := range keys {
+		vals[i] = local[k]
+	}
+	sums := make([]int, len(keys))
+	err = cm.AllReduceInt(OpSum, sums, vals)
+	if err != nil {
+		return nil, err
+	}
+	global := make(map[string]int, len(keys))
+	for i, k := range keys {
+		global[k] = sums[i]
+	}
+	return global, nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// Displacements returns the displacements (offsets) for values tiled
+// contiguously in rank order according to given counts per proc,
+// along with the total count, as used in the Gatherv, AllGatherv,
+// and AllToAllv methods.  The values for proc i in the resulting flat
+// buffer are buf[displs[i] : displs[i]+counts[i]].
+func Displacements(counts []int) (displs []int, total int) {
+	displs = make([]int, len(counts))
+	for i, c := range counts {
+		displs[i] = total
+		total += c
+	}
+	return
+}
+
+// checkCounts returns an error if any of the given counts is negative,
+// or if any region buf[displs[i] : displs[i]+counts[i]] falls outside
+// of a buffer of length n.  counts and displs must have the same length.
+// name is the method name and buf is the buffer name, for the error.
+func checkCounts(name, buf string, n int, counts, displs []int) error {
+	for i, c := range counts {
+		if c < 0 {
+			return errorf("mpi.%s: counts[%d] %d is negative", name, i, c)
+		}
+		if d := displs[i]; d < 0 || d+c > n {
+			return errorf("mpi.%s: displs[%d] %d + counts[%d] %d is out of range for len(%s): %d", name, i, d, i, c, buf, n)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDisplacements(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts []int
+		displs []int
+		total  int
+	}{
+		{"nil", nil, []int{}, 0},
+		{"empty", []int{}, []int{}, 0},
+		{"zeros", []int{0, 0, 0}, []int{0, 0, 0}, 0},
+		{"single", []int{5}, []int{0}, 5},
+		{"mixed", []int{3, 0, 2, 4}, []int{0, 3, 3, 5}, 9},
+		{"trailing zero", []int{1, 2, 0}, []int{0, 1, 3}, 3},
+	}
+	for _, tt := range tests {
+		displs, total := Displacements(tt.counts)
+		if !slices.Equal(displs, tt.displs) {
+			t.Errorf("%s: displs: got %v, want %v", tt.name, displs, tt.displs)
+		}
+		if total != tt.total {
+			t.Errorf("%s: total: got %d, want %d", tt.name, total, tt.total)
+		}
+	}
+}
+
+func TestCheckCounts(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      int
+		counts []int
+		displs []int
+		ok     bool
+	}{
+		{"empty", 0, []int{}, []int{}, true},
+		{"tiled", 9, []int{3, 0, 2, 4}, []int{0, 3, 3, 5}, true},
+		{"gaps", 10, []int{2, 2}, []int{0, 8}, true},
+		{"negative count", 9, []int{3, -1}, []int{0, 3}, false},
+		{"negative displ", 9, []int{3, 1}, []int{-1, 3}, false},
+		{"past end", 8, []int{3, 0, 2, 4}, []int{0, 3, 3, 5}, false},
+	}
+	for _, tt := range tests {
+		err := checkCounts("Test", "buf", tt.n, tt.counts, tt.displs)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: got err %v, want ok %v", tt.name, err, tt.ok)
+		}
+	}
+}
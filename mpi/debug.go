@@ -0,0 +1,65 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi && mpidebug
+
+package mpi
+
+/*
+#cgo pkg-config: ompi
+#include "mpi.h"
+*/
+import "C"
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"runtime"
+	"strconv"
+)
+
+// checkTypeSizes checks that the size of each Go element type matches
+// the size of the MPI datatype used to transfer it, logging a loud error
+// for any mismatch, which would otherwise silently transfer the wrong
+// number of bytes.  This is only done in the mpidebug build.
+func checkTypeSizes() {
+	for _, ts := range typeSizes() {
+		var sz C.int
+		C.MPI_Type_size(ts.dt, &sz)
+		if uintptr(sz) != ts.size {
+			log.Printf("mpi: ERROR: datatype size mismatch for %s: Go element size: %d != MPI datatype size: %d -- transfers will be corrupted!\n", ts.name, ts.size, sz)
+		}
+	}
+}
+
+// enterCollective is called at the start of each collective call, and returns
+// the function to call at the end of it.  In the mpidebug build, unless MPI
+// was initialized with MPI_THREAD_MULTIPLE using InitThreadSafe, it panics if
+// another goroutine is already in a collective call on this Comm, which would
+// otherwise cause random data corruption.
+func (cm *Comm) enterCollective() func() {
+	cm.traceCollective()
+	if threadMultiple {
+		return nopExit
+	}
+	gid := goroutineID()
+	if !cm.inCollective.CompareAndSwap(0, gid) {
+		panic(fmt.Sprintf("mpi: collective called from goroutine %d while goroutine %d is in a collective on the same Comm, without MPI_THREAD_MULTIPLE (see InitThreadSafe)", gid, cm.inCollective.Load()))
+	}
+	return func() { cm.inCollective.Store(0) }
+}
+
+// goroutineID returns the id of the current goroutine,
+// parsed from the runtime stack trace header.
+func goroutineID() int64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseInt(string(b), 10, 64)
+	return id
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// AllReduceDeterministicF64 reduces all values across procs to all procs from
+// orig into dest using given operation, like AllReduceF64, but always combining
+// the values in the same fixed rank order, so that floating point results are
+// bitwise identical across runs with the same number of procs.
+// The standard AllReduce can reorder the operations from run to run,
+// producing slightly different results due to floating point rounding.
+// This works by gathering all values to the Root proc, combining them there
+// in rank order, and broadcasting the result back to all procs,
+// so it is significantly slower than AllReduceF64, and requires np * len(orig)
+// memory on Root: only use it when reproducibility is required.
+// Only OpSum, OpProd, OpMax, and OpMin are supported.
+// IMPORTANT: orig and dest must be different slices, of the same length.
+func (cm *Comm) AllReduceDeterministicF64(op Op, dest, orig []float64) error {
+	return allReduceOrdered(cm, op, dest, orig, cm.GatherF64, cm.BcastF64, "AllReduceDeterministicF64")
+}
+
+// AllReduceDeterministicF32 reduces all values across procs to all procs from
+// orig into dest using given operation, like AllReduceF32, but always combining
+// the values in the same fixed rank order, so that floating point results are
+// bitwise identical across runs with the same number of procs.
+// See AllReduceDeterministicF64 for details, including the performance cost.
+// IMPORTANT: orig and dest must be different slices, of the same length.
+func (cm *Comm) AllReduceDeterministicF32(op Op, dest, orig []float32) error {
+	return allReduceOrdered(cm, op, dest, orig, cm.GatherF32, cm.BcastF32, "AllReduceDeterministicF32")
+}
+
+// allReduceOrdered implements the deterministic AllReduce using given
+// typed Gather and Bcast methods.
+func allReduceOrdered[T float32 | float64](cm *Comm, op Op, dest, orig []T, gather func(toProc int, dest, orig []T) error, bcast func(fmProc int, vals []T) error, ctxt string) error {
+	n := len(orig)
+	if len(dest) != n {
+		return errorf("mpi.%s: len(dest) %d != len(orig) %d", ctxt, len(dest), n)
+	}
+	switch op {
+	case OpSum, OpProd, OpMax, OpMin:
+	default:
+		return errorf("mpi.%s: Op %d is not supported", ctxt, op)
+	}
+	if n == 0 {
+		return nil
+	}
+	np := cm.Size()
+	if np == 1 {
+		copy(dest, orig)
+		return nil
+	}
+	isRoot := cm.Rank() == Root
+	var all []T
+	if isRoot {
+		all = make([]T, np*n)
+	}
+	err := gather(Root, all, orig)
+	if err != nil {
+		return err
+	}
+	if isRoot {
+		copy(dest, all[:n])
+		for p := 1; p < np; p++ {
+			combineOp(op, dest, all[p*n:(p+1)*n])
+		}
+	}
+	return bcast(Root, dest)
+}
+
+// combineOp combines vals into dest using given operation,
+// which must be OpSum, OpProd, OpMax, or OpMin.
+func combineOp[T float32 | float64](op Op, dest, vals []T) {
+	for i, v := range vals {
+		switch op {
+		case OpSum:
+			dest[i] += v
+		case OpProd:
+			dest[i] *= v
+		case OpMax:
+			dest[i] = max(dest[i], v)
+		case OpMin:
+			dest[i] = min(dest[i], v)
+		}
+	}
+}
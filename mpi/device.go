@@ -0,0 +1,77 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi && mpicuda
+
+package mpi
+
+/*
+#cgo pkg-config: ompi
+#include "mpi.h"
+
+extern MPI_Datatype FLOAT32;
+extern MPI_Datatype FLOAT64;
+*/
+import "C"
+
+import "unsafe"
+
+// this file provides methods that operate directly on GPU device memory,
+// which requires a CUDA-aware MPI build (e.g., OpenMPI configured with
+// --with-cuda), and is only included with the mpicuda build tag.
+// On an MPI build that is not CUDA-aware, passing a device pointer
+// will crash or produce garbage.  Use ompi_info --parsable --all | grep
+// mpi_built_with_cuda_support to check.
+
+// AllReduceF32Device reduces n float32 values in GPU device memory at devPtr
+// across procs, in place, using given operation, passing the device pointer
+// directly to MPI to avoid copying the values to and from host memory.
+// The GPU must be done writing the values (e.g., stream synchronized)
+// before calling.  Requires a CUDA-aware MPI build.
+func (cm *Comm) AllReduceF32Device(op Op, devPtr unsafe.Pointer, n int) error {
+	cm.countMetric("AllReduceDevice", n*int(unsafe.Sizeof(float32(0))))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceF32Device"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceF32Device"); err != nil {
+		return err
+	}
+	if n == 0 {
+		return nil
+	}
+	return Error(C.MPI_Allreduce(C.MPI_IN_PLACE, devPtr, C.int(n), C.FLOAT32, op.ToC(), cm.comm), "AllReduceF32Device")
+}
+
+// AllReduceF64Device reduces n float64 values in GPU device memory at devPtr
+// across procs, in place, using given operation, passing the device pointer
+// directly to MPI to avoid copying the values to and from host memory.
+// The GPU must be done writing the values (e.g., stream synchronized)
+// before calling.  Requires a CUDA-aware MPI build.
+func (cm *Comm) AllReduceF64Device(op Op, devPtr unsafe.Pointer, n int) error {
+	cm.countMetric("AllReduceDevice", n*int(unsafe.Sizeof(float64(0))))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceF64Device"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceF64Device"); err != nil {
+		return err
+	}
+	if n == 0 {
+		return nil
+	}
+	return Error(C.MPI_Allreduce(C.MPI_IN_PLACE, devPtr, C.int(n), C.FLOAT64, op.ToC(), cm.comm), "AllReduceF64Device")
+}
+
+// BcastF32Device broadcasts n float32 values in GPU device memory at devPtr
+// from proc fmProc to all other procs, passing the device pointer
+// directly to MPI.  Requires a CUDA-aware MPI build.
+func (cm *Comm) BcastF32Device(fmProc int, devPtr unsafe.Pointer, n int) error {
+	cm.countMetric("BcastDevice", n*int(unsafe.Sizeof(float32(0))))
+	defer cm.enterCollective()()
+	if n == 0 {
+		return nil
+	}
+	return Error(C.MPI_Bcast(devPtr, C.int(n), C.FLOAT32, C.int(fmProc), cm.comm), "BcastF32Device")
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !mpi && mpicuda
+
+package mpi
+
+import "unsafe"
+
+// AllReduceF32Device reduces n float32 values in GPU device memory at devPtr
+// across procs, in place, using given operation, passing the device pointer
+// directly to MPI to avoid copying the values to and from host memory.
+// The GPU must be done writing the values (e.g., stream synchronized)
+// before calling.  Requires a CUDA-aware MPI build.
+func (cm *Comm) AllReduceF32Device(op Op, devPtr unsafe.Pointer, n int) error {
+	return nil
+}
+
+// AllReduceF64Device reduces n float64 values in GPU device memory at devPtr
+// across procs, in place, using given operation, passing the device pointer
+// directly to MPI to avoid copying the values to and from host memory.
+// The GPU must be done writing the values (e.g., stream synchronized)
+// before calling.  Requires a CUDA-aware MPI build.
+func (cm *Comm) AllReduceF64Device(op Op, devPtr unsafe.Pointer, n int) error {
+	return nil
+}
+
+// BcastF32Device broadcasts n float32 values in GPU device memory at devPtr
+// from proc fmProc to all other procs, passing the device pointer
+// directly to MPI.  Requires a CUDA-aware MPI build.
+func (cm *Comm) BcastF32Device(fmProc int, devPtr unsafe.Pointer, n int) error {
+	return nil
+}
@@ -10,7 +10,17 @@ The wrapping code was initially copied  from https://github.com/cpmech/gosl/mpi
 and significantly modified.
 
 All standard Go types are supported using the apache arrow tmpl generation tool.
-Int is assumed to be 64bit and is defined as a []int because that is typically
-more convenient.
+Int is defined as a []int because that is typically more convenient,
+and uses the MPI datatype matching the size of int on the current platform
+(64bit on 64bit platforms, 32bit otherwise).  Use the I32 or I64 methods
+to transfer fixed-size integers: the I32 methods (e.g., ReduceI32, AllReduceI32)
+use MPI_INT, matching the 32bit C int layout used by most external C code.
+
+All of the point-to-point methods (Send, Recv, Isend, Irecv, and the
+SendStream and SendRange variants) take an explicit message tag, so that
+multiple independent exchanges between the same procs can be in flight
+at the same time, using different tags.  The helpers that use
+point-to-point messages internally (e.g., BarrierDiag) use fixed tags
+of 7000 and above, so application tags should be below that.
 */
 package mpi
@@ -0,0 +1,30 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// DrainAndFinalize waits for all of the given outstanding non-blocking
+// requests to complete (nil entries are skipped), synchronizes all procs
+// with a Barrier, and then calls Finalize, for a clean shutdown.
+// Calling Finalize while requests are still pending can cause MPI
+// to report an error or hang.  It must be called on all procs in the
+// communicator, in place of Finalize, passing all of the requests that
+// each proc has started and not yet completed.  Finalize is called
+// even if there are errors, and the first error is returned.
+func (cm *Comm) DrainAndFinalize(reqs []*Request) error {
+	var err error
+	for _, r := range reqs {
+		if r == nil {
+			continue
+		}
+		if werr := r.Wait(); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	if berr := cm.Barrier(); berr != nil && err == nil {
+		err = berr
+	}
+	Finalize()
+	return err
+}
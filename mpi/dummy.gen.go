@@ -14,12 +14,28 @@ func (cm *Comm) SendF64(toProc int, tag int, vals []float64) error {
 	return nil
 }
 
-// Recv64F64 receives values from proc fmProc, using given unique tag identifier
+// RecvF64 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvF64(fmProc int, tag int, vals []float64) error {
 	return nil
 }
 
+// IsendF64 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendF64(toProc int, tag int, vals []float64) (*Request, error) {
+	return &Request{}, nil
+}
+
+// IrecvF64 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvF64(fmProc int, tag int, vals []float64) (*Request, error) {
+	return &Request{}, nil
+}
+
 // BcastF64 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastF64(fmProc int, vals []float64) error {
@@ -27,6 +43,8 @@ func (cm *Comm) BcastF64(fmProc int, vals []float64) error {
 }
 
 // ReduceF64 reduces all values across procs to toProc in orig to dest using given operation.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceF64(toProc int, op Op, dest, orig []float64) error {
 	return nil
@@ -38,13 +56,60 @@ func (cm *Comm) AllReduceF64(op Op, dest, orig []float64) error {
 	return nil
 }
 
+// IAllReduceF64 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceF64(op Op, dest, orig []float64) (*Request, error) {
+	return &Request{}, nil
+}
+
+// ScanF64 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanF64(op Op, dest, orig []float64) error {
+	return nil
+}
+
+// ExScanF64 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanF64(op Op, dest, orig []float64) error {
+	return nil
+}
+
 // GatherF64 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherF64(toProc int, dest, orig []float64) error {
 	return nil
 }
 
+// GatherInPlaceF64 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceF64(toProc int, buf []float64) error {
+	return nil
+}
+
+// GathervF64 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervF64(toProc int, dest, orig []float64, counts, displs []int) error {
+	return nil
+}
+
 // AllGatherF64 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
@@ -52,25 +117,91 @@ func (cm *Comm) AllGatherF64(dest, orig []float64) error {
 	return nil
 }
 
+// AllGathervF64 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervF64(dest, orig []float64, counts, displs []int) error {
+	return nil
+}
+
+// AllGatherInPlaceF64 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceF64(buf []float64) error {
+	return nil
+}
+
 // ScatterF64 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterF64(fmProc int, dest, orig []float64) error {
 	return nil
 }
 
+// ScattervF64 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervF64(fmProc int, dest, orig []float64, counts, displs []int) error {
+	return nil
+}
+
+// AllToAllF64 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllF64(dest, orig []float64) error {
+	return nil
+}
+
+// AllToAllvF64 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvF64(dest, orig []float64, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	return nil
+}
+
 // SendF32 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendF32(toProc int, tag int, vals []float32) error {
 	return nil
 }
 
-// Recv64F32 receives values from proc fmProc, using given unique tag identifier
+// RecvF32 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvF32(fmProc int, tag int, vals []float32) error {
 	return nil
 }
 
+// IsendF32 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendF32(toProc int, tag int, vals []float32) (*Request, error) {
+	return &Request{}, nil
+}
+
+// IrecvF32 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvF32(fmProc int, tag int, vals []float32) (*Request, error) {
+	return &Request{}, nil
+}
+
 // BcastF32 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastF32(fmProc int, vals []float32) error {
@@ -78,6 +209,8 @@ func (cm *Comm) BcastF32(fmProc int, vals []float32) error {
 }
 
 // ReduceF32 reduces all values across procs to toProc in orig to dest using given operation.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceF32(toProc int, op Op, dest, orig []float32) error {
 	return nil
@@ -89,13 +222,60 @@ func (cm *Comm) AllReduceF32(op Op, dest, orig []float32) error {
 	return nil
 }
 
+// IAllReduceF32 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceF32(op Op, dest, orig []float32) (*Request, error) {
+	return &Request{}, nil
+}
+
+// ScanF32 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanF32(op Op, dest, orig []float32) error {
+	return nil
+}
+
+// ExScanF32 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanF32(op Op, dest, orig []float32) error {
+	return nil
+}
+
 // GatherF32 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherF32(toProc int, dest, orig []float32) error {
 	return nil
 }
 
+// GatherInPlaceF32 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceF32(toProc int, buf []float32) error {
+	return nil
+}
+
+// GathervF32 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervF32(toProc int, dest, orig []float32, counts, displs []int) error {
+	return nil
+}
+
 // AllGatherF32 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
@@ -103,25 +283,91 @@ func (cm *Comm) AllGatherF32(dest, orig []float32) error {
 	return nil
 }
 
+// AllGathervF32 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervF32(dest, orig []float32, counts, displs []int) error {
+	return nil
+}
+
+// AllGatherInPlaceF32 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceF32(buf []float32) error {
+	return nil
+}
+
 // ScatterF32 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterF32(fmProc int, dest, orig []float32) error {
 	return nil
 }
 
+// ScattervF32 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervF32(fmProc int, dest, orig []float32, counts, displs []int) error {
+	return nil
+}
+
+// AllToAllF32 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllF32(dest, orig []float32) error {
+	return nil
+}
+
+// AllToAllvF32 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvF32(dest, orig []float32, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	return nil
+}
+
 // SendInt sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendInt(toProc int, tag int, vals []int) error {
 	return nil
 }
 
-// Recv64Int receives values from proc fmProc, using given unique tag identifier
+// RecvInt receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvInt(fmProc int, tag int, vals []int) error {
 	return nil
 }
 
+// IsendInt starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendInt(toProc int, tag int, vals []int) (*Request, error) {
+	return &Request{}, nil
+}
+
+// IrecvInt starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvInt(fmProc int, tag int, vals []int) (*Request, error) {
+	return &Request{}, nil
+}
+
 // BcastInt broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastInt(fmProc int, vals []int) error {
@@ -129,6 +375,8 @@ func (cm *Comm) BcastInt(fmProc int, vals []int) error {
 }
 
 // ReduceInt reduces all values across procs to toProc in orig to dest using given operation.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceInt(toProc int, op Op, dest, orig []int) error {
 	return nil
@@ -140,13 +388,60 @@ func (cm *Comm) AllReduceInt(op Op, dest, orig []int) error {
 	return nil
 }
 
+// IAllReduceInt starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceInt(op Op, dest, orig []int) (*Request, error) {
+	return &Request{}, nil
+}
+
+// ScanInt computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanInt(op Op, dest, orig []int) error {
+	return nil
+}
+
+// ExScanInt computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanInt(op Op, dest, orig []int) error {
+	return nil
+}
+
 // GatherInt gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherInt(toProc int, dest, orig []int) error {
 	return nil
 }
 
+// GatherInPlaceInt gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceInt(toProc int, buf []int) error {
+	return nil
+}
+
+// GathervInt gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervInt(toProc int, dest, orig []int, counts, displs []int) error {
+	return nil
+}
+
 // AllGatherInt gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
@@ -154,25 +449,91 @@ func (cm *Comm) AllGatherInt(dest, orig []int) error {
 	return nil
 }
 
+// AllGathervInt gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervInt(dest, orig []int, counts, displs []int) error {
+	return nil
+}
+
+// AllGatherInPlaceInt gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceInt(buf []int) error {
+	return nil
+}
+
 // ScatterInt scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterInt(fmProc int, dest, orig []int) error {
 	return nil
 }
 
+// ScattervInt scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervInt(fmProc int, dest, orig []int, counts, displs []int) error {
+	return nil
+}
+
+// AllToAllInt sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllInt(dest, orig []int) error {
+	return nil
+}
+
+// AllToAllvInt sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvInt(dest, orig []int, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	return nil
+}
+
 // SendI64 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendI64(toProc int, tag int, vals []int64) error {
 	return nil
 }
 
-// Recv64I64 receives values from proc fmProc, using given unique tag identifier
+// RecvI64 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvI64(fmProc int, tag int, vals []int64) error {
 	return nil
 }
 
+// IsendI64 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendI64(toProc int, tag int, vals []int64) (*Request, error) {
+	return &Request{}, nil
+}
+
+// IrecvI64 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvI64(fmProc int, tag int, vals []int64) (*Request, error) {
+	return &Request{}, nil
+}
+
 // BcastI64 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastI64(fmProc int, vals []int64) error {
@@ -180,6 +541,8 @@ func (cm *Comm) BcastI64(fmProc int, vals []int64) error {
 }
 
 // ReduceI64 reduces all values across procs to toProc in orig to dest using given operation.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceI64(toProc int, op Op, dest, orig []int64) error {
 	return nil
@@ -191,13 +554,60 @@ func (cm *Comm) AllReduceI64(op Op, dest, orig []int64) error {
 	return nil
 }
 
+// IAllReduceI64 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceI64(op Op, dest, orig []int64) (*Request, error) {
+	return &Request{}, nil
+}
+
+// ScanI64 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanI64(op Op, dest, orig []int64) error {
+	return nil
+}
+
+// ExScanI64 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanI64(op Op, dest, orig []int64) error {
+	return nil
+}
+
 // GatherI64 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherI64(toProc int, dest, orig []int64) error {
 	return nil
 }
 
+// GatherInPlaceI64 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceI64(toProc int, buf []int64) error {
+	return nil
+}
+
+// GathervI64 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervI64(toProc int, dest, orig []int64, counts, displs []int) error {
+	return nil
+}
+
 // AllGatherI64 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
@@ -205,25 +615,91 @@ func (cm *Comm) AllGatherI64(dest, orig []int64) error {
 	return nil
 }
 
+// AllGathervI64 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervI64(dest, orig []int64, counts, displs []int) error {
+	return nil
+}
+
+// AllGatherInPlaceI64 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceI64(buf []int64) error {
+	return nil
+}
+
 // ScatterI64 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterI64(fmProc int, dest, orig []int64) error {
 	return nil
 }
 
+// ScattervI64 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervI64(fmProc int, dest, orig []int64, counts, displs []int) error {
+	return nil
+}
+
+// AllToAllI64 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllI64(dest, orig []int64) error {
+	return nil
+}
+
+// AllToAllvI64 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvI64(dest, orig []int64, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	return nil
+}
+
 // SendU64 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendU64(toProc int, tag int, vals []uint64) error {
 	return nil
 }
 
-// Recv64U64 receives values from proc fmProc, using given unique tag identifier
+// RecvU64 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvU64(fmProc int, tag int, vals []uint64) error {
 	return nil
 }
 
+// IsendU64 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendU64(toProc int, tag int, vals []uint64) (*Request, error) {
+	return &Request{}, nil
+}
+
+// IrecvU64 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvU64(fmProc int, tag int, vals []uint64) (*Request, error) {
+	return &Request{}, nil
+}
+
 // BcastU64 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastU64(fmProc int, vals []uint64) error {
@@ -231,6 +707,8 @@ func (cm *Comm) BcastU64(fmProc int, vals []uint64) error {
 }
 
 // ReduceU64 reduces all values across procs to toProc in orig to dest using given operation.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceU64(toProc int, op Op, dest, orig []uint64) error {
 	return nil
@@ -242,13 +720,60 @@ func (cm *Comm) AllReduceU64(op Op, dest, orig []uint64) error {
 	return nil
 }
 
+// IAllReduceU64 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceU64(op Op, dest, orig []uint64) (*Request, error) {
+	return &Request{}, nil
+}
+
+// ScanU64 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanU64(op Op, dest, orig []uint64) error {
+	return nil
+}
+
+// ExScanU64 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanU64(op Op, dest, orig []uint64) error {
+	return nil
+}
+
 // GatherU64 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherU64(toProc int, dest, orig []uint64) error {
 	return nil
 }
 
+// GatherInPlaceU64 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceU64(toProc int, buf []uint64) error {
+	return nil
+}
+
+// GathervU64 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervU64(toProc int, dest, orig []uint64, counts, displs []int) error {
+	return nil
+}
+
 // AllGatherU64 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
@@ -256,25 +781,91 @@ func (cm *Comm) AllGatherU64(dest, orig []uint64) error {
 	return nil
 }
 
+// AllGathervU64 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervU64(dest, orig []uint64, counts, displs []int) error {
+	return nil
+}
+
+// AllGatherInPlaceU64 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceU64(buf []uint64) error {
+	return nil
+}
+
 // ScatterU64 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterU64(fmProc int, dest, orig []uint64) error {
 	return nil
 }
 
+// ScattervU64 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervU64(fmProc int, dest, orig []uint64, counts, displs []int) error {
+	return nil
+}
+
+// AllToAllU64 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllU64(dest, orig []uint64) error {
+	return nil
+}
+
+// AllToAllvU64 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvU64(dest, orig []uint64, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	return nil
+}
+
 // SendI32 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendI32(toProc int, tag int, vals []int32) error {
 	return nil
 }
 
-// Recv64I32 receives values from proc fmProc, using given unique tag identifier
+// RecvI32 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvI32(fmProc int, tag int, vals []int32) error {
 	return nil
 }
 
+// IsendI32 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendI32(toProc int, tag int, vals []int32) (*Request, error) {
+	return &Request{}, nil
+}
+
+// IrecvI32 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvI32(fmProc int, tag int, vals []int32) (*Request, error) {
+	return &Request{}, nil
+}
+
 // BcastI32 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastI32(fmProc int, vals []int32) error {
@@ -282,6 +873,8 @@ func (cm *Comm) BcastI32(fmProc int, vals []int32) error {
 }
 
 // ReduceI32 reduces all values across procs to toProc in orig to dest using given operation.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceI32(toProc int, op Op, dest, orig []int32) error {
 	return nil
@@ -293,13 +886,60 @@ func (cm *Comm) AllReduceI32(op Op, dest, orig []int32) error {
 	return nil
 }
 
+// IAllReduceI32 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceI32(op Op, dest, orig []int32) (*Request, error) {
+	return &Request{}, nil
+}
+
+// ScanI32 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanI32(op Op, dest, orig []int32) error {
+	return nil
+}
+
+// ExScanI32 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanI32(op Op, dest, orig []int32) error {
+	return nil
+}
+
 // GatherI32 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherI32(toProc int, dest, orig []int32) error {
 	return nil
 }
 
+// GatherInPlaceI32 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceI32(toProc int, buf []int32) error {
+	return nil
+}
+
+// GathervI32 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervI32(toProc int, dest, orig []int32, counts, displs []int) error {
+	return nil
+}
+
 // AllGatherI32 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
@@ -307,25 +947,91 @@ func (cm *Comm) AllGatherI32(dest, orig []int32) error {
 	return nil
 }
 
+// AllGathervI32 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervI32(dest, orig []int32, counts, displs []int) error {
+	return nil
+}
+
+// AllGatherInPlaceI32 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceI32(buf []int32) error {
+	return nil
+}
+
 // ScatterI32 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterI32(fmProc int, dest, orig []int32) error {
 	return nil
 }
 
+// ScattervI32 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervI32(fmProc int, dest, orig []int32, counts, displs []int) error {
+	return nil
+}
+
+// AllToAllI32 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllI32(dest, orig []int32) error {
+	return nil
+}
+
+// AllToAllvI32 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvI32(dest, orig []int32, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	return nil
+}
+
 // SendU32 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendU32(toProc int, tag int, vals []uint32) error {
 	return nil
 }
 
-// Recv64U32 receives values from proc fmProc, using given unique tag identifier
+// RecvU32 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvU32(fmProc int, tag int, vals []uint32) error {
 	return nil
 }
 
+// IsendU32 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendU32(toProc int, tag int, vals []uint32) (*Request, error) {
+	return &Request{}, nil
+}
+
+// IrecvU32 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvU32(fmProc int, tag int, vals []uint32) (*Request, error) {
+	return &Request{}, nil
+}
+
 // BcastU32 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastU32(fmProc int, vals []uint32) error {
@@ -333,6 +1039,8 @@ func (cm *Comm) BcastU32(fmProc int, vals []uint32) error {
 }
 
 // ReduceU32 reduces all values across procs to toProc in orig to dest using given operation.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceU32(toProc int, op Op, dest, orig []uint32) error {
 	return nil
@@ -344,11 +1052,58 @@ func (cm *Comm) AllReduceU32(op Op, dest, orig []uint32) error {
 	return nil
 }
 
-// GatherU32 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
-// This is inverse of Scatter.
-// IMPORTANT: orig and dest must be different slices.
-func (cm *Comm) GatherU32(toProc int, dest, orig []uint32) error {
-	return nil
+// IAllReduceU32 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceU32(op Op, dest, orig []uint32) (*Request, error) {
+	return &Request{}, nil
+}
+
+// ScanU32 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanU32(op Op, dest, orig []uint32) error {
+	return nil
+}
+
+// ExScanU32 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanU32(op Op, dest, orig []uint32) error {
+	return nil
+}
+
+// GatherU32 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
+// This is inverse of Scatter.
+// dest is ignored on all procs except toProc, and may be nil on them.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GatherU32(toProc int, dest, orig []uint32) error {
+	return nil
+}
+
+// GatherInPlaceU32 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceU32(toProc int, buf []uint32) error {
+	return nil
+}
+
+// GathervU32 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervU32(toProc int, dest, orig []uint32, counts, displs []int) error {
+	return nil
 }
 
 // AllGatherU32 gathers values from all procs into all procs,
@@ -358,25 +1113,91 @@ func (cm *Comm) AllGatherU32(dest, orig []uint32) error {
 	return nil
 }
 
+// AllGathervU32 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervU32(dest, orig []uint32, counts, displs []int) error {
+	return nil
+}
+
+// AllGatherInPlaceU32 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceU32(buf []uint32) error {
+	return nil
+}
+
 // ScatterU32 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterU32(fmProc int, dest, orig []uint32) error {
 	return nil
 }
 
+// ScattervU32 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervU32(fmProc int, dest, orig []uint32, counts, displs []int) error {
+	return nil
+}
+
+// AllToAllU32 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllU32(dest, orig []uint32) error {
+	return nil
+}
+
+// AllToAllvU32 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvU32(dest, orig []uint32, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	return nil
+}
+
 // SendI16 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendI16(toProc int, tag int, vals []int16) error {
 	return nil
 }
 
-// Recv64I16 receives values from proc fmProc, using given unique tag identifier
+// RecvI16 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvI16(fmProc int, tag int, vals []int16) error {
 	return nil
 }
 
+// IsendI16 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendI16(toProc int, tag int, vals []int16) (*Request, error) {
+	return &Request{}, nil
+}
+
+// IrecvI16 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvI16(fmProc int, tag int, vals []int16) (*Request, error) {
+	return &Request{}, nil
+}
+
 // BcastI16 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastI16(fmProc int, vals []int16) error {
@@ -384,6 +1205,8 @@ func (cm *Comm) BcastI16(fmProc int, vals []int16) error {
 }
 
 // ReduceI16 reduces all values across procs to toProc in orig to dest using given operation.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceI16(toProc int, op Op, dest, orig []int16) error {
 	return nil
@@ -395,13 +1218,60 @@ func (cm *Comm) AllReduceI16(op Op, dest, orig []int16) error {
 	return nil
 }
 
+// IAllReduceI16 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceI16(op Op, dest, orig []int16) (*Request, error) {
+	return &Request{}, nil
+}
+
+// ScanI16 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanI16(op Op, dest, orig []int16) error {
+	return nil
+}
+
+// ExScanI16 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanI16(op Op, dest, orig []int16) error {
+	return nil
+}
+
 // GatherI16 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherI16(toProc int, dest, orig []int16) error {
 	return nil
 }
 
+// GatherInPlaceI16 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceI16(toProc int, buf []int16) error {
+	return nil
+}
+
+// GathervI16 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervI16(toProc int, dest, orig []int16, counts, displs []int) error {
+	return nil
+}
+
 // AllGatherI16 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
@@ -409,25 +1279,91 @@ func (cm *Comm) AllGatherI16(dest, orig []int16) error {
 	return nil
 }
 
+// AllGathervI16 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervI16(dest, orig []int16, counts, displs []int) error {
+	return nil
+}
+
+// AllGatherInPlaceI16 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceI16(buf []int16) error {
+	return nil
+}
+
 // ScatterI16 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterI16(fmProc int, dest, orig []int16) error {
 	return nil
 }
 
+// ScattervI16 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervI16(fmProc int, dest, orig []int16, counts, displs []int) error {
+	return nil
+}
+
+// AllToAllI16 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllI16(dest, orig []int16) error {
+	return nil
+}
+
+// AllToAllvI16 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvI16(dest, orig []int16, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	return nil
+}
+
 // SendU16 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendU16(toProc int, tag int, vals []uint16) error {
 	return nil
 }
 
-// Recv64U16 receives values from proc fmProc, using given unique tag identifier
+// RecvU16 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvU16(fmProc int, tag int, vals []uint16) error {
 	return nil
 }
 
+// IsendU16 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendU16(toProc int, tag int, vals []uint16) (*Request, error) {
+	return &Request{}, nil
+}
+
+// IrecvU16 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvU16(fmProc int, tag int, vals []uint16) (*Request, error) {
+	return &Request{}, nil
+}
+
 // BcastU16 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastU16(fmProc int, vals []uint16) error {
@@ -435,6 +1371,8 @@ func (cm *Comm) BcastU16(fmProc int, vals []uint16) error {
 }
 
 // ReduceU16 reduces all values across procs to toProc in orig to dest using given operation.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceU16(toProc int, op Op, dest, orig []uint16) error {
 	return nil
@@ -446,13 +1384,60 @@ func (cm *Comm) AllReduceU16(op Op, dest, orig []uint16) error {
 	return nil
 }
 
+// IAllReduceU16 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceU16(op Op, dest, orig []uint16) (*Request, error) {
+	return &Request{}, nil
+}
+
+// ScanU16 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanU16(op Op, dest, orig []uint16) error {
+	return nil
+}
+
+// ExScanU16 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanU16(op Op, dest, orig []uint16) error {
+	return nil
+}
+
 // GatherU16 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherU16(toProc int, dest, orig []uint16) error {
 	return nil
 }
 
+// GatherInPlaceU16 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceU16(toProc int, buf []uint16) error {
+	return nil
+}
+
+// GathervU16 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervU16(toProc int, dest, orig []uint16, counts, displs []int) error {
+	return nil
+}
+
 // AllGatherU16 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
@@ -460,25 +1445,91 @@ func (cm *Comm) AllGatherU16(dest, orig []uint16) error {
 	return nil
 }
 
+// AllGathervU16 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervU16(dest, orig []uint16, counts, displs []int) error {
+	return nil
+}
+
+// AllGatherInPlaceU16 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceU16(buf []uint16) error {
+	return nil
+}
+
 // ScatterU16 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterU16(fmProc int, dest, orig []uint16) error {
 	return nil
 }
 
+// ScattervU16 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervU16(fmProc int, dest, orig []uint16, counts, displs []int) error {
+	return nil
+}
+
+// AllToAllU16 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllU16(dest, orig []uint16) error {
+	return nil
+}
+
+// AllToAllvU16 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvU16(dest, orig []uint16, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	return nil
+}
+
 // SendI8 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendI8(toProc int, tag int, vals []int8) error {
 	return nil
 }
 
-// Recv64I8 receives values from proc fmProc, using given unique tag identifier
+// RecvI8 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvI8(fmProc int, tag int, vals []int8) error {
 	return nil
 }
 
+// IsendI8 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendI8(toProc int, tag int, vals []int8) (*Request, error) {
+	return &Request{}, nil
+}
+
+// IrecvI8 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvI8(fmProc int, tag int, vals []int8) (*Request, error) {
+	return &Request{}, nil
+}
+
 // BcastI8 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastI8(fmProc int, vals []int8) error {
@@ -486,6 +1537,8 @@ func (cm *Comm) BcastI8(fmProc int, vals []int8) error {
 }
 
 // ReduceI8 reduces all values across procs to toProc in orig to dest using given operation.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceI8(toProc int, op Op, dest, orig []int8) error {
 	return nil
@@ -497,13 +1550,60 @@ func (cm *Comm) AllReduceI8(op Op, dest, orig []int8) error {
 	return nil
 }
 
+// IAllReduceI8 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceI8(op Op, dest, orig []int8) (*Request, error) {
+	return &Request{}, nil
+}
+
+// ScanI8 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanI8(op Op, dest, orig []int8) error {
+	return nil
+}
+
+// ExScanI8 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanI8(op Op, dest, orig []int8) error {
+	return nil
+}
+
 // GatherI8 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherI8(toProc int, dest, orig []int8) error {
 	return nil
 }
 
+// GatherInPlaceI8 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceI8(toProc int, buf []int8) error {
+	return nil
+}
+
+// GathervI8 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervI8(toProc int, dest, orig []int8, counts, displs []int) error {
+	return nil
+}
+
 // AllGatherI8 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
@@ -511,25 +1611,91 @@ func (cm *Comm) AllGatherI8(dest, orig []int8) error {
 	return nil
 }
 
+// AllGathervI8 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervI8(dest, orig []int8, counts, displs []int) error {
+	return nil
+}
+
+// AllGatherInPlaceI8 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceI8(buf []int8) error {
+	return nil
+}
+
 // ScatterI8 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterI8(fmProc int, dest, orig []int8) error {
 	return nil
 }
 
+// ScattervI8 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervI8(fmProc int, dest, orig []int8, counts, displs []int) error {
+	return nil
+}
+
+// AllToAllI8 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllI8(dest, orig []int8) error {
+	return nil
+}
+
+// AllToAllvI8 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvI8(dest, orig []int8, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	return nil
+}
+
 // SendU8 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendU8(toProc int, tag int, vals []uint8) error {
 	return nil
 }
 
-// Recv64U8 receives values from proc fmProc, using given unique tag identifier
+// RecvU8 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvU8(fmProc int, tag int, vals []uint8) error {
 	return nil
 }
 
+// IsendU8 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendU8(toProc int, tag int, vals []uint8) (*Request, error) {
+	return &Request{}, nil
+}
+
+// IrecvU8 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvU8(fmProc int, tag int, vals []uint8) (*Request, error) {
+	return &Request{}, nil
+}
+
 // BcastU8 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastU8(fmProc int, vals []uint8) error {
@@ -537,6 +1703,8 @@ func (cm *Comm) BcastU8(fmProc int, vals []uint8) error {
 }
 
 // ReduceU8 reduces all values across procs to toProc in orig to dest using given operation.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceU8(toProc int, op Op, dest, orig []uint8) error {
 	return nil
@@ -548,13 +1716,60 @@ func (cm *Comm) AllReduceU8(op Op, dest, orig []uint8) error {
 	return nil
 }
 
+// IAllReduceU8 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceU8(op Op, dest, orig []uint8) (*Request, error) {
+	return &Request{}, nil
+}
+
+// ScanU8 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanU8(op Op, dest, orig []uint8) error {
+	return nil
+}
+
+// ExScanU8 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanU8(op Op, dest, orig []uint8) error {
+	return nil
+}
+
 // GatherU8 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherU8(toProc int, dest, orig []uint8) error {
 	return nil
 }
 
+// GatherInPlaceU8 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceU8(toProc int, buf []uint8) error {
+	return nil
+}
+
+// GathervU8 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervU8(toProc int, dest, orig []uint8, counts, displs []int) error {
+	return nil
+}
+
 // AllGatherU8 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
@@ -562,25 +1777,91 @@ func (cm *Comm) AllGatherU8(dest, orig []uint8) error {
 	return nil
 }
 
+// AllGathervU8 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervU8(dest, orig []uint8, counts, displs []int) error {
+	return nil
+}
+
+// AllGatherInPlaceU8 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceU8(buf []uint8) error {
+	return nil
+}
+
 // ScatterU8 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterU8(fmProc int, dest, orig []uint8) error {
 	return nil
 }
 
+// ScattervU8 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervU8(fmProc int, dest, orig []uint8, counts, displs []int) error {
+	return nil
+}
+
+// AllToAllU8 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllU8(dest, orig []uint8) error {
+	return nil
+}
+
+// AllToAllvU8 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvU8(dest, orig []uint8, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	return nil
+}
+
 // SendC128 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendC128(toProc int, tag int, vals []complex128) error {
 	return nil
 }
 
-// Recv64C128 receives values from proc fmProc, using given unique tag identifier
+// RecvC128 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvC128(fmProc int, tag int, vals []complex128) error {
 	return nil
 }
 
+// IsendC128 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendC128(toProc int, tag int, vals []complex128) (*Request, error) {
+	return &Request{}, nil
+}
+
+// IrecvC128 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvC128(fmProc int, tag int, vals []complex128) (*Request, error) {
+	return &Request{}, nil
+}
+
 // BcastC128 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastC128(fmProc int, vals []complex128) error {
@@ -588,6 +1869,8 @@ func (cm *Comm) BcastC128(fmProc int, vals []complex128) error {
 }
 
 // ReduceC128 reduces all values across procs to toProc in orig to dest using given operation.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceC128(toProc int, op Op, dest, orig []complex128) error {
 	return nil
@@ -599,13 +1882,60 @@ func (cm *Comm) AllReduceC128(op Op, dest, orig []complex128) error {
 	return nil
 }
 
+// IAllReduceC128 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceC128(op Op, dest, orig []complex128) (*Request, error) {
+	return &Request{}, nil
+}
+
+// ScanC128 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanC128(op Op, dest, orig []complex128) error {
+	return nil
+}
+
+// ExScanC128 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanC128(op Op, dest, orig []complex128) error {
+	return nil
+}
+
 // GatherC128 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherC128(toProc int, dest, orig []complex128) error {
 	return nil
 }
 
+// GatherInPlaceC128 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceC128(toProc int, buf []complex128) error {
+	return nil
+}
+
+// GathervC128 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervC128(toProc int, dest, orig []complex128, counts, displs []int) error {
+	return nil
+}
+
 // AllGatherC128 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
@@ -613,25 +1943,91 @@ func (cm *Comm) AllGatherC128(dest, orig []complex128) error {
 	return nil
 }
 
+// AllGathervC128 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervC128(dest, orig []complex128, counts, displs []int) error {
+	return nil
+}
+
+// AllGatherInPlaceC128 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceC128(buf []complex128) error {
+	return nil
+}
+
 // ScatterC128 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterC128(fmProc int, dest, orig []complex128) error {
 	return nil
 }
 
+// ScattervC128 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervC128(fmProc int, dest, orig []complex128, counts, displs []int) error {
+	return nil
+}
+
+// AllToAllC128 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllC128(dest, orig []complex128) error {
+	return nil
+}
+
+// AllToAllvC128 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvC128(dest, orig []complex128, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	return nil
+}
+
 // SendC64 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendC64(toProc int, tag int, vals []complex64) error {
 	return nil
 }
 
-// Recv64C64 receives values from proc fmProc, using given unique tag identifier
+// RecvC64 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvC64(fmProc int, tag int, vals []complex64) error {
 	return nil
 }
 
+// IsendC64 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendC64(toProc int, tag int, vals []complex64) (*Request, error) {
+	return &Request{}, nil
+}
+
+// IrecvC64 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvC64(fmProc int, tag int, vals []complex64) (*Request, error) {
+	return &Request{}, nil
+}
+
 // BcastC64 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastC64(fmProc int, vals []complex64) error {
@@ -639,6 +2035,8 @@ func (cm *Comm) BcastC64(fmProc int, vals []complex64) error {
 }
 
 // ReduceC64 reduces all values across procs to toProc in orig to dest using given operation.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceC64(toProc int, op Op, dest, orig []complex64) error {
 	return nil
@@ -650,13 +2048,60 @@ func (cm *Comm) AllReduceC64(op Op, dest, orig []complex64) error {
 	return nil
 }
 
+// IAllReduceC64 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceC64(op Op, dest, orig []complex64) (*Request, error) {
+	return &Request{}, nil
+}
+
+// ScanC64 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanC64(op Op, dest, orig []complex64) error {
+	return nil
+}
+
+// ExScanC64 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanC64(op Op, dest, orig []complex64) error {
+	return nil
+}
+
 // GatherC64 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherC64(toProc int, dest, orig []complex64) error {
 	return nil
 }
 
+// GatherInPlaceC64 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceC64(toProc int, buf []complex64) error {
+	return nil
+}
+
+// GathervC64 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervC64(toProc int, dest, orig []complex64, counts, displs []int) error {
+	return nil
+}
+
 // AllGatherC64 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
@@ -664,9 +2109,59 @@ func (cm *Comm) AllGatherC64(dest, orig []complex64) error {
 	return nil
 }
 
+// AllGathervC64 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervC64(dest, orig []complex64, counts, displs []int) error {
+	return nil
+}
+
+// AllGatherInPlaceC64 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceC64(buf []complex64) error {
+	return nil
+}
+
 // ScatterC64 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterC64(fmProc int, dest, orig []complex64) error {
 	return nil
 }
+
+// ScattervC64 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervC64(fmProc int, dest, orig []complex64, counts, displs []int) error {
+	return nil
+}
+
+// AllToAllC64 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllC64(dest, orig []complex64) error {
+	return nil
+}
+
+// AllToAllvC64 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvC64(dest, orig []complex64, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	return nil
+}
@@ -7,13 +7,28 @@
 
 package mpi
 
+import (
+	"os"
+	"time"
+)
+
 // this file provides dummy versions, built by default, so mpi can be included
 // generically without incurring additional complexity.
 
 // set LogErrors to control whether MPI errors are automatically logged or not
 var LogErrors = true
 
+// set CheckABIAtInit to have Init check that the MPI run time library
+// matches the headers used at compile time, using CheckABI
+var CheckABIAtInit = false
+
+// threadMultiple is never set in the dummy build
+var threadMultiple bool
+
 // Op is an aggregation operation: Sum, Min, Max, etc
+// OpMax and OpMin are not defined for complex types (C64, C128):
+// use AllReduceC128MaxAbs or AllReduceC64MaxAbs instead.
+// Custom float32 operations can be created with NewOp.
 type Op int
 
 const (
@@ -25,11 +40,25 @@ const (
 	OpLOR  // logical OR
 	OpBAND // bitwise AND
 	OpBOR  // bitwise OR
+
+	// OpMaxLoc and OpMinLoc keep the maximum or minimum value along with
+	// its index, and are only valid for the MaxLoc and MinLoc methods,
+	// which reduce value-index pairs.
+	OpMaxLoc
+	OpMinLoc
 )
 
 const (
 	// Root is the rank 0 node -- it is more semantic to use this
 	Root int = 0
+
+	// AnySource can be passed as the fmProc to Recv methods
+	// to receive a message from any proc
+	AnySource int = -1
+
+	// AnyTag can be passed as the tag to Recv methods
+	// to receive a message with any tag
+	AnyTag int = -1
 )
 
 // IsOn tells whether MPI is on or not
@@ -64,10 +93,60 @@ func WorldSize() (size int) {
 	return 1
 }
 
+// wtimeStart is the reference time for Wtime
+var wtimeStart = time.Now()
+
+// Wtime returns the elapsed wall-clock time in seconds since an arbitrary
+// point in the past, which is fixed for the life of the process.
+// Use differences between calls to measure elapsed time.
+func Wtime() float64 {
+	return time.Since(wtimeStart).Seconds()
+}
+
+// Wtick returns the resolution of Wtime in seconds, i.e., the time
+// between successive ticks of the clock.
+func Wtick() float64 {
+	return 1.0e-9
+}
+
+// ProcessorName returns the name of the processor (host) that this proc
+// is running on, as reported by MPI, e.g., for logging the placement
+// of procs on hosts.
+func ProcessorName() string {
+	nm, _ := os.Hostname()
+	return nm
+}
+
 // Comm is the MPI communicator -- all MPI communication operates as methods
 // on this struct.  It holds the MPI_Comm communicator and MPI_Group for
 // sub-World group communication.
 type Comm struct {
+	name string
+
+	// subs are cached sub-communicators, keyed by ranks
+	subs map[string]*Comm
+
+	// progress is the state for ProgressSum
+	progress *progressState
+
+	// heartbeat is the state for Heartbeat
+	heartbeat *heartbeatState
+
+	// barrierDiag is the state for BarrierDiag
+	barrierDiag *barrierDiagState
+
+	// localRanks are the ranks on the local host, cached by LocalRanks
+	localRanks []int
+
+	// metrics are the communication counts, when EnableMetrics is on
+	metrics *metricsState
+
+	// scratchF32 is the buffer returned by ScratchF32
+	scratchF32 []float32
+
+	// nCollectives is the number of collective calls made,
+	// when TraceCollectives is on
+	nCollectives int
 }
 
 // NewComm creates a new communicator.
@@ -78,6 +157,247 @@ func NewComm(ranks []int) (*Comm, error) {
 	return cm, nil
 }
 
+// dup returns a duplicate of this communicator, with the same procs
+// but a separate communication space, which must be called on all procs.
+func (cm *Comm) dup() (*Comm, error) {
+	return &Comm{}, nil
+}
+
+// newSubComm creates a new communicator for given ranks within this
+// communicator, which must be called on all procs in this communicator.
+// Returns nil for procs that are not in ranks.
+func (cm *Comm) newSubComm(ranks []int) (*Comm, error) {
+	for _, r := range ranks {
+		if r == 0 {
+			return &Comm{}, nil
+		}
+	}
+	return nil, nil
+}
+
+// ExcludeRanks returns a new communicator containing all the procs in this
+// one except those with given ranks (world ranks for the World communicator),
+// e.g., to continue in a degraded mode without a proc known to be bad.
+// This complements NewComm, which takes the ranks to include.
+// It must be called on all procs in this communicator, with the same ranks,
+// and returns nil for the excluded procs.  Call Free when done with it.
+func (cm *Comm) ExcludeRanks(ranks []int) (*Comm, error) {
+	for _, r := range ranks {
+		if r == 0 {
+			return nil, nil
+		}
+	}
+	return &Comm{}, nil
+}
+
+// Free releases the MPI resources held by this communicator, including
+// any cached sub-communicators and the private communicators used by
+// ProgressSum, Heartbeat and BarrierDiag, and sets its handles to null, so that it
+// must not be used after this.  It must be called on all procs in the
+// communicator, after any pending operations have completed.
+// The World MPI communicator itself must not be freed, so it is not,
+// but its group is, and this Comm can no longer be used.
+func (cm *Comm) Free() error {
+	cm.subs = nil
+	cm.progress = nil
+	cm.heartbeat = nil
+	cm.barrierDiag = nil
+	return nil
+}
+
+// split returns a new communicator containing the procs in this one that
+// pass the same color, ordered by key, which must be called on all procs.
+func (cm *Comm) split(color, key int) (*Comm, error) {
+	return &Comm{}, nil
+}
+
+// splitTypeShared returns a new communicator containing the procs in this
+// one that are on the same host as this proc, sharing memory, which must be
+// called on all procs.  Ranks in the new communicator are in the same order
+// as in this one.
+func (cm *Comm) splitTypeShared() (*Comm, error) {
+	return &Comm{}, nil
+}
+
+// SplitTypeNUMA returns a new communicator containing the procs in this one
+// that share the same NUMA domain (e.g., CPU socket) as this proc, which must
+// be called on all procs.  Ranks in the new communicator are in the same order
+// as in this one.  This is useful for the innermost tier of hierarchical
+// reductions (NUMA domain, then node, then across nodes) on large multi-socket
+// nodes.  It uses the OpenMPI-specific OMPI_COMM_TYPE_NUMA split type,
+// and returns a nil Comm and an error if this is not supported.
+func (cm *Comm) SplitTypeNUMA() (*Comm, error) {
+	return &Comm{}, nil
+}
+
+// AllToAllw sends a separate buffer of bytes to each proc, sendBufs[i] going
+// to proc i, and receives a separate buffer from each proc into recvBufs,
+// with recvBufs[i] coming from proc i, using MPI_Alltoallw.
+// This is the most general all-to-all exchange: each buffer can be of a
+// different size, and can hold a different type of data (e.g., int values
+// for one proc and float32 values for another, as encoded by the caller),
+// to implement arbitrary data shuffles.  For the common case of the same
+// type of data, use the typed AllToAllv methods instead.
+// Both must have one buffer per proc, and len(recvBufs[i]) must equal
+// len(sendBufs[rank]) on proc i.
+func (cm *Comm) AllToAllw(sendBufs, recvBufs [][]byte) error {
+	return nil
+}
+
+// AllReduceKahanSumF32 sums the values in buf across all procs, in place,
+// with better accuracy than AllReduceF32 with OpSum, using Kahan-compensated
+// summation: each value is reduced along with a compensation term that
+// accumulates the rounding error of each float32 addition (computed exactly
+// with the TwoSum algorithm), using a custom MPI operation, and the
+// compensation is added back into the sum at the end.  The error of the
+// result is thus about one float32 rounding, instead of growing with the
+// number of procs as for OpSum, which is important for sums of many values
+// of different magnitudes, e.g., averaging float32 gradients across many procs.
+// The cost is twice the communication volume of AllReduceF32, plus a
+// temporary buffer of value-compensation pairs of the same length as buf.
+func (cm *Comm) AllReduceKahanSumF32(buf []float32) error {
+	return nil
+}
+
+// AllReduceC128MaxAbs reduces the values in buf across all procs, in place,
+// keeping the value with the largest magnitude (absolute value) for each element,
+// using a custom MPI operation.  OpMax and OpMin are not defined by MPI
+// for complex types, so this is the correct substitute for finding the maximum.
+// In case of equal magnitudes, any one of the values may be kept.
+func (cm *Comm) AllReduceC128MaxAbs(buf []complex128) error {
+	return nil
+}
+
+// AllReduceC64MaxAbs reduces the values in buf across all procs, in place,
+// keeping the value with the largest magnitude (absolute value) for each element,
+// using a custom MPI operation.  OpMax and OpMin are not defined by MPI
+// for complex types, so this is the correct substitute for finding the maximum.
+// In case of equal magnitudes, any one of the values may be kept.
+func (cm *Comm) AllReduceC64MaxAbs(buf []complex64) error {
+	return nil
+}
+
+// AllReduceMinLocF64 reduces all values across procs to all procs from orig
+// into dest, keeping the minimum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MINLOC.
+// The index typically identifies where the value came from, e.g., the rank
+// of the proc, and in case of ties the lowest index is kept.
+// An error is returned for indexes outside the 32bit int range.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) AllReduceMinLocF64(dest, orig []float64, destIdx, origIdx []int) error {
+	return nil
+}
+
+// AllReduceMaxLocF64 reduces all values across procs to all procs from orig
+// into dest, keeping the maximum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MAXLOC.
+// The index typically identifies where the value came from, e.g., the rank
+// of the proc, and in case of ties the lowest index is kept.
+// An error is returned for indexes outside the 32bit int range.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) AllReduceMaxLocF64(dest, orig []float64, destIdx, origIdx []int) error {
+	return nil
+}
+
+// ReduceMinLocF64 reduces all values across procs to toProc from orig
+// into dest, keeping the minimum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MINLOC.
+// This is the standard pattern for finding which proc holds the global
+// minimum, by passing the rank of each proc in origIdx.
+// In case of ties the lowest index is kept.  An error is returned for
+// indexes outside the 32bit int range.
+// dest and destIdx are ignored on all procs except toProc, and may be nil on them.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) ReduceMinLocF64(toProc int, dest, orig []float64, destIdx, origIdx []int) error {
+	return nil
+}
+
+// ReduceMaxLocF64 reduces all values across procs to toProc from orig
+// into dest, keeping the maximum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MAXLOC.
+// This is the standard pattern for finding which proc holds the global
+// maximum, by passing the rank of each proc in origIdx.
+// In case of ties the lowest index is kept.  An error is returned for
+// indexes outside the 32bit int range.
+// dest and destIdx are ignored on all procs except toProc, and may be nil on them.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) ReduceMaxLocF64(toProc int, dest, orig []float64, destIdx, origIdx []int) error {
+	return nil
+}
+
+// AllReduceMinLocF32 reduces all values across procs to all procs from orig
+// into dest, keeping the minimum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MINLOC.
+// The index typically identifies where the value came from, e.g., the rank
+// of the proc, and in case of ties the lowest index is kept.
+// An error is returned for indexes outside the 32bit int range.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) AllReduceMinLocF32(dest, orig []float32, destIdx, origIdx []int) error {
+	return nil
+}
+
+// AllReduceMaxLocF32 reduces all values across procs to all procs from orig
+// into dest, keeping the maximum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MAXLOC.
+// The index typically identifies where the value came from, e.g., the rank
+// of the proc, and in case of ties the lowest index is kept.
+// An error is returned for indexes outside the 32bit int range.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) AllReduceMaxLocF32(dest, orig []float32, destIdx, origIdx []int) error {
+	return nil
+}
+
+// ReduceMinLocF32 reduces all values across procs to toProc from orig
+// into dest, keeping the minimum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MINLOC.
+// This is the standard pattern for finding which proc holds the global
+// minimum, by passing the rank of each proc in origIdx.
+// In case of ties the lowest index is kept.  An error is returned for
+// indexes outside the 32bit int range.
+// dest and destIdx are ignored on all procs except toProc, and may be nil on them.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) ReduceMinLocF32(toProc int, dest, orig []float32, destIdx, origIdx []int) error {
+	return nil
+}
+
+// ReduceMaxLocF32 reduces all values across procs to toProc from orig
+// into dest, keeping the maximum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MAXLOC.
+// This is the standard pattern for finding which proc holds the global
+// maximum, by passing the rank of each proc in origIdx.
+// In case of ties the lowest index is kept.  An error is returned for
+// indexes outside the 32bit int range.
+// dest and destIdx are ignored on all procs except toProc, and may be nil on them.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) ReduceMaxLocF32(toProc int, dest, orig []float32, destIdx, origIdx []int) error {
+	return nil
+}
+
+// LibraryVersion returns the version string of the MPI library
+// that is being used at run time, which is empty when not built with mpi.
+func LibraryVersion() string {
+	return ""
+}
+
+// CheckABI checks that the MPI library used at run time matches the version
+// of the MPI headers that this package was compiled against, returning an
+// error, and logging a loud warning, if not.
+func CheckABI() error {
+	return nil
+}
+
+// AllReduceStridedF32 reduces count values across procs to all procs from orig
+// into dest using given operation, for the strided elements at
+// offset + i * stride, for i < count (e.g., one channel of an interleaved layout),
+// which are stored into the same elements of dest, leaving the others unchanged.
+// This uses an MPI vector datatype to describe the strided elements,
+// avoiding the need to copy them into a contiguous buffer.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) AllReduceStridedF32(op Op, dest, orig []float32, count, stride, offset int) error {
+	return nil
+}
+
 // Rank returns the rank/ID for this proc
 func (cm *Comm) Rank() (rank int) {
 	return 0
@@ -88,12 +408,43 @@ func (cm *Comm) Size() (size int) {
 	return 1
 }
 
+// Refresh updates the cached Rank and Size of this proc in this communicator,
+// from MPI, and clears the cached LocalRanks.  These do not change over the
+// life of a communicator, so this is only needed in the rare case that the
+// underlying MPI communicator is replaced (e.g., by dynamic process management).
+func (cm *Comm) Refresh() {
+	cm.localRanks = nil
+}
+
+// SetName sets the name of this communicator, which is used to identify
+// it in log messages (see Logf), and in MPI error messages and tools.
+func (cm *Comm) SetName(name string) error {
+	cm.name = name
+	return nil
+}
+
+// Name returns the name of this communicator, as set by SetName
+func (cm *Comm) Name() string {
+	return cm.name
+}
+
 // Abort aborts MPI
 func (cm *Comm) Abort() error {
 	return nil
 }
 
+// abort aborts MPI with given exit code
+func (cm *Comm) abort(code int) error {
+	return nil
+}
+
 // Barrier forces synchronisation
 func (cm *Comm) Barrier() error {
 	return nil
 }
+
+// IBarrier starts a non-blocking barrier, returning a Request that
+// completes when all procs in the communicator have entered the barrier.
+func (cm *Comm) IBarrier() (*Request, error) {
+	return &Request{}, nil
+}
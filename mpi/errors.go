@@ -0,0 +1,21 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import (
+	"fmt"
+	"log"
+)
+
+// errorf returns an error for a problem detected on the Go side,
+// prior to calling MPI (e.g., invalid buffer sizes), and logs it
+// if LogErrors is set, consistent with MPI errors.
+func errorf(format string, args ...any) error {
+	err := fmt.Errorf(format, args...)
+	if LogErrors {
+		log.Println(err)
+	}
+	return err
+}
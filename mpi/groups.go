@@ -0,0 +1,69 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// Dup returns a duplicate of this communicator, with the same procs in the
+// same order, but a separate communication space, so that messages and
+// collective calls on it cannot interfere with those on this one, e.g.,
+// for use by a library alongside an application that also uses MPI.
+// This must be called on all procs.  Call Free when done with it.
+func (cm *Comm) Dup() (*Comm, error) {
+	return cm.dup()
+}
+
+// Split divides the procs in this Comm into new communicators, one for
+// each distinct color, with the procs passing the same color in the same
+// communicator, ordered by key (and then by rank in this Comm for equal
+// keys), returning the new communicator for this proc's color.
+// Unlike NewComm, each proc only needs to know its own color, e.g., for
+// running multiple independent model replicas within one job.
+// color must be non-negative.  This must be called on all procs.
+// Call Free when done with the new communicator.
+func (cm *Comm) Split(color, key int) (*Comm, error) {
+	if color < 0 {
+		return nil, errorf("mpi.Split: color must be non-negative: %d", color)
+	}
+	return cm.split(color, key)
+}
+
+// SplitIntoGroups divides the procs in this Comm into consecutive groups of
+// groupSize procs each (e.g., the stages of a model for pipeline parallelism),
+// returning a new communicator for the group that this proc is in, along
+// with the index of that group.  Ranks in the group communicator are in the
+// same order as in this one.  Returns an error if Size is not an even
+// multiple of groupSize.  This must be called on all procs.
+// See SplitAcrossGroups for the complementary communicator.
+func (cm *Comm) SplitIntoGroups(groupSize int) (groupComm *Comm, groupID int, err error) {
+	if err = cm.checkGroupSize(groupSize, "SplitIntoGroups"); err != nil {
+		return
+	}
+	rank := cm.Rank()
+	groupID = rank / groupSize
+	groupComm, err = cm.split(groupID, rank%groupSize)
+	return
+}
+
+// SplitAcrossGroups returns a new communicator connecting the procs at the
+// same position within each of the consecutive groups of groupSize procs
+// used in SplitIntoGroups, e.g., all of the procs for a given pipeline stage,
+// for data-parallel reductions within that stage.  Together with
+// SplitIntoGroups, this gives the full 2D grid decomposition of the procs.
+// Ranks in the new communicator are in group order.  Returns an error if
+// Size is not an even multiple of groupSize.  This must be called on all procs.
+func (cm *Comm) SplitAcrossGroups(groupSize int) (crossComm *Comm, err error) {
+	if err = cm.checkGroupSize(groupSize, "SplitAcrossGroups"); err != nil {
+		return
+	}
+	rank := cm.Rank()
+	return cm.split(rank%groupSize, rank/groupSize)
+}
+
+// checkGroupSize returns an error if groupSize does not evenly divide Size.
+func (cm *Comm) checkGroupSize(groupSize int, ctxt string) error {
+	if groupSize < 1 || cm.Size()%groupSize != 0 {
+		return errorf("mpi.%s: number of procs: %d is not an even multiple of groupSize: %d", ctxt, cm.Size(), groupSize)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// HeartbeatMaxLag is the maximum number of Heartbeat calls that can be
+// pending (not yet completed by all procs) before Heartbeat returns an error
+// indicating that a proc may be stalled.
+var HeartbeatMaxLag = 4
+
+// heartbeatState holds the state for Heartbeat.
+type heartbeatState struct {
+	// comm is a private duplicate of the communicator, so that the
+	// pending heartbeats do not interfere with other collective operations.
+	comm *Comm
+
+	// pending are the heartbeats that have not yet completed, in order.
+	pending []*heartbeat
+}
+
+// heartbeat is one pending Heartbeat call.
+type heartbeat struct {
+	seq       int
+	req       *Request
+	orig, res []int
+}
+
+// Heartbeat is a cheap periodic check that all procs are alive and in sync,
+// for long jobs, which should be called by all procs at the same points,
+// e.g., every N steps, with a monotonically increasing, non-negative
+// sequence number (e.g., the step count).  Each call starts a non-blocking
+// reduction of the sequence numbers, so faster procs are not stalled waiting
+// for slower ones, and checks the results of prior calls that have completed.
+// An error is returned naming the lagging proc if a completed heartbeat shows
+// that the procs had different sequence numbers, indicating that they have
+// diverged, or if more than HeartbeatMaxLag heartbeats are still pending,
+// indicating that some proc is stalled or has failed.
+// All pending heartbeats are completed by HeartbeatDone, which all procs
+// must call at the end.
+func (cm *Comm) Heartbeat(seq int) error {
+	np := cm.Size()
+	if np == 1 {
+		return nil
+	}
+	hs := cm.heartbeat
+	if hs == nil {
+		hc, err := cm.dup()
+		if err != nil {
+			return err
+		}
+		hs = &heartbeatState{comm: hc}
+		cm.heartbeat = hs
+	}
+	hb := &heartbeat{seq: seq, orig: []int{seq*np + cm.Rank(), -seq}, res: make([]int, 2)}
+	req, err := hs.comm.IAllReduceInt(OpMin, hb.res, hb.orig)
+	if err != nil {
+		return err
+	}
+	hb.req = req
+	hs.pending = append(hs.pending, hb)
+	for len(hs.pending) > 0 {
+		hb := hs.pending[0]
+		done, err := hb.req.Test()
+		if err != nil {
+			return err
+		}
+		if !done {
+			break
+		}
+		hs.pending = hs.pending[1:]
+		if err := hb.check(np); err != nil {
+			return err
+		}
+	}
+	if len(hs.pending) > HeartbeatMaxLag {
+		return errorf("mpi.Heartbeat: %d heartbeats not yet completed by all procs, oldest sequence: %d: a proc may be stalled", len(hs.pending), hs.pending[0].seq)
+	}
+	return nil
+}
+
+// HeartbeatDone waits for all pending Heartbeat calls to complete,
+// checking their results.  It must be called by all procs at the end.
+func (cm *Comm) HeartbeatDone() error {
+	hs := cm.heartbeat
+	if hs == nil {
+		return nil
+	}
+	var rerr error
+	for _, hb := range hs.pending {
+		if err := hb.req.Wait(); err != nil {
+			return err
+		}
+		if err := hb.check(cm.Size()); err != nil && rerr == nil {
+			rerr = err
+		}
+	}
+	hs.pending = nil
+	return rerr
+}
+
+// check checks the results of a completed heartbeat.
+func (hb *heartbeat) check(np int) error {
+	mn, mx := hb.res[0]/np, -hb.res[1]
+	if mn != mx {
+		return errorf("mpi.Heartbeat: proc %d is behind, at sequence %d, vs. max of %d (this proc: %d)", hb.res[0]%np, mn, mx, hb.seq)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+
+package mpi
+
+/*
+#cgo pkg-config: ompi
+#include "mpi.h"
+
+extern MPI_Datatype COMPLEX64;
+
+// kahanSumF32 combines (sum, compensation) pairs of floats, stored as
+// COMPLEX64 values, using TwoSum to compute the exact rounding error
+// of the sum, which is added to the compensation.
+static void kahanSumF32(void *in, void *inout, int *len, MPI_Datatype *dt) {
+	float *a = (float *)in;
+	float *b = (float *)inout;
+	for (int i = 0; i < 2 * *len; i += 2) {
+		float s = a[i] + b[i];
+		float bp = s - a[i];
+		float err = (a[i] - (s - bp)) + (b[i] - bp);
+		b[i] = s;
+		b[i+1] = (a[i+1] + b[i+1]) + err;
+	}
+}
+
+static int createKahanSumOp(MPI_Op *op) {
+	return MPI_Op_create(kahanSumF32, 1, op);
+}
+*/
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+)
+
+var (
+	kahanSumOnce sync.Once
+	kahanSumErr  error
+	kahanSumOp   C.MPI_Op
+)
+
+// kahanSumOps creates the Kahan sum op the first time it is called.
+func kahanSumOps() error {
+	kahanSumOnce.Do(func() {
+		kahanSumErr = Error(C.createKahanSumOp(&kahanSumOp), "Op_create")
+	})
+	return kahanSumErr
+}
+
+// AllReduceKahanSumF32 sums the values in buf across all procs, in place,
+// with better accuracy than AllReduceF32 with OpSum, using Kahan-compensated
+// summation: each value is reduced along with a compensation term that
+// accumulates the rounding error of each float32 addition (computed exactly
+// with the TwoSum algorithm), using a custom MPI operation, and the
+// compensation is added back into the sum at the end.  The error of the
+// result is thus about one float32 rounding, instead of growing with the
+// number of procs as for OpSum, which is important for sums of many values
+// of different magnitudes, e.g., averaging float32 gradients across many procs.
+// The cost is twice the communication volume of AllReduceF32, plus a
+// temporary buffer of value-compensation pairs of the same length as buf.
+func (cm *Comm) AllReduceKahanSumF32(buf []float32) error {
+	cm.countMetric("AllReduceKahanSum", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if cm.Size() == 1 || len(buf) == 0 {
+		return nil
+	}
+	if err := kahanSumOps(); err != nil {
+		return err
+	}
+	pairs := make([]complex64, len(buf))
+	for i, v := range buf {
+		pairs[i] = complex(v, 0)
+	}
+	err := Error(C.MPI_Allreduce(C.MPI_IN_PLACE, bufPtr(pairs), C.int(len(pairs)), C.COMPLEX64, kahanSumOp, cm.comm), "AllReduceKahanSumF32")
+	if err != nil {
+		return err
+	}
+	for i, p := range pairs {
+		buf[i] = real(p) + imag(p)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import "os"
+
+// LauncherEnvVars are the environment variables that MPI launchers
+// (mpirun / mpiexec for OpenMPI, MPICH Hydra, and PMIx-based launchers
+// such as srun) set for each proc they start.  If none of these are set,
+// the program was not started by a launcher.  See IsSingleton.
+var LauncherEnvVars = []string{
+	"OMPI_COMM_WORLD_SIZE",
+	"OMPI_COMM_WORLD_RANK",
+	"PMI_SIZE",
+	"PMI_RANK",
+	"PMIX_RANK",
+}
+
+// IsLaunched returns true if this proc was started by an MPI launcher
+// such as mpirun, as detected by the presence of any of the LauncherEnvVars.
+func IsLaunched() bool {
+	for _, ev := range LauncherEnvVars {
+		if _, has := os.LookupEnv(ev); has {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSingleton returns true if there is only one proc in the World
+// and it was not started by an MPI launcher (i.e., the binary was run
+// directly instead of via mpirun).  In this case MPI_Init can still succeed,
+// but collectives and communicator splits may behave unexpectedly,
+// so it is best to deliberately take the serial code path instead.
+// Detection is based on WorldSize and the LauncherEnvVars.
+func IsSingleton() bool {
+	return WorldSize() == 1 && !IsLaunched()
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+
+package mpi
+
+/*
+#cgo pkg-config: ompi
+#include "mpi.h"
+*/
+import "C"
+
+import (
+	"math"
+	"unsafe"
+)
+
+// locPair matches the C struct {value; int} layout of the MPI_DOUBLE_INT
+// and MPI_FLOAT_INT value-index pair datatypes.
+type locPair[T float32 | float64] struct {
+	val T
+	idx int32
+}
+
+// AllReduceMinLocF64 reduces all values across procs to all procs from orig
+// into dest, keeping the minimum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MINLOC.
+// The index typically identifies where the value came from, e.g., the rank
+// of the proc, and in case of ties the lowest index is kept.
+// An error is returned for indexes outside the 32bit int range.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) AllReduceMinLocF64(dest, orig []float64, destIdx, origIdx []int) error {
+	return reduceLoc(cm, -1, OpMinLoc, C.MPI_DOUBLE_INT, dest, orig, destIdx, origIdx, "AllReduceMinLocF64")
+}
+
+// AllReduceMaxLocF64 reduces all values across procs to all procs from orig
+// into dest, keeping the maximum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MAXLOC.
+// The index typically identifies where the value came from, e.g., the rank
+// of the proc, and in case of ties the lowest index is kept.
+// An error is returned for indexes outside the 32bit int range.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) AllReduceMaxLocF64(dest, orig []float64, destIdx, origIdx []int) error {
+	return reduceLoc(cm, -1, OpMaxLoc, C.MPI_DOUBLE_INT, dest, orig, destIdx, origIdx, "AllReduceMaxLocF64")
+}
+
+// ReduceMinLocF64 reduces all values across procs to toProc from orig
+// into dest, keeping the minimum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MINLOC.
+// This is the standard pattern for finding which proc holds the global
+// minimum, by passing the rank of each proc in origIdx.
+// In case of ties the lowest index is kept.  An error is returned for
+// indexes outside the 32bit int range.
+// dest and destIdx are ignored on all procs except toProc, and may be nil on them.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) ReduceMinLocF64(toProc int, dest, orig []float64, destIdx, origIdx []int) error {
+	return reduceLoc(cm, toProc, OpMinLoc, C.MPI_DOUBLE_INT, dest, orig, destIdx, origIdx, "ReduceMinLocF64")
+}
+
+// ReduceMaxLocF64 reduces all values across procs to toProc from orig
+// into dest, keeping the maximum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MAXLOC.
+// This is the standard pattern for finding which proc holds the global
+// maximum, by passing the rank of each proc in origIdx.
+// In case of ties the lowest index is kept.  An error is returned for
+// indexes outside the 32bit int range.
+// dest and destIdx are ignored on all procs except toProc, and may be nil on them.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) ReduceMaxLocF64(toProc int, dest, orig []float64, destIdx, origIdx []int) error {
+	return reduceLoc(cm, toProc, OpMaxLoc, C.MPI_DOUBLE_INT, dest, orig, destIdx, origIdx, "ReduceMaxLocF64")
+}
+
+// AllReduceMinLocF32 reduces all values across procs to all procs from orig
+// into dest, keeping the minimum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MINLOC.
+// The index typically identifies where the value came from, e.g., the rank
+// of the proc, and in case of ties the lowest index is kept.
+// An error is returned for indexes outside the 32bit int range.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) AllReduceMinLocF32(dest, orig []float32, destIdx, origIdx []int) error {
+	return reduceLoc(cm, -1, OpMinLoc, C.MPI_FLOAT_INT, dest, orig, destIdx, origIdx, "AllReduceMinLocF32")
+}
+
+// AllReduceMaxLocF32 reduces all values across procs to all procs from orig
+// into dest, keeping the maximum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MAXLOC.
+// The index typically identifies where the value came from, e.g., the rank
+// of the proc, and in case of ties the lowest index is kept.
+// An error is returned for indexes outside the 32bit int range.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) AllReduceMaxLocF32(dest, orig []float32, destIdx, origIdx []int) error {
+	return reduceLoc(cm, -1, OpMaxLoc, C.MPI_FLOAT_INT, dest, orig, destIdx, origIdx, "AllReduceMaxLocF32")
+}
+
+// ReduceMinLocF32 reduces all values across procs to toProc from orig
+// into dest, keeping the minimum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MINLOC.
+// This is the standard pattern for finding which proc holds the global
+// minimum, by passing the rank of each proc in origIdx.
+// In case of ties the lowest index is kept.  An error is returned for
+// indexes outside the 32bit int range.
+// dest and destIdx are ignored on all procs except toProc, and may be nil on them.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) ReduceMinLocF32(toProc int, dest, orig []float32, destIdx, origIdx []int) error {
+	return reduceLoc(cm, toProc, OpMinLoc, C.MPI_FLOAT_INT, dest, orig, destIdx, origIdx, "ReduceMinLocF32")
+}
+
+// ReduceMaxLocF32 reduces all values across procs to toProc from orig
+// into dest, keeping the maximum value for each element, along with the
+// corresponding index from origIdx into destIdx, using MPI_MAXLOC.
+// This is the standard pattern for finding which proc holds the global
+// maximum, by passing the rank of each proc in origIdx.
+// In case of ties the lowest index is kept.  An error is returned for
+// indexes outside the 32bit int range.
+// dest and destIdx are ignored on all procs except toProc, and may be nil on them.
+// IMPORTANT: all slices must be different, of the same length.
+func (cm *Comm) ReduceMaxLocF32(toProc int, dest, orig []float32, destIdx, origIdx []int) error {
+	return reduceLoc(cm, toProc, OpMaxLoc, C.MPI_FLOAT_INT, dest, orig, destIdx, origIdx, "ReduceMaxLocF32")
+}
+
+// reduceLoc implements the MINLOC and MAXLOC methods, packing the values
+// and indexes into value-index pairs of given MPI datatype, and reducing
+// them to toProc, or to all procs if toProc < 0.  Errors in the arguments
+// are returned after the collective call, so the other procs do not block.
+func reduceLoc[T float32 | float64](cm *Comm, toProc int, op Op, dt C.MPI_Datatype, dest, orig []T, destIdx, origIdx []int, ctxt string) error {
+	// metrics are keyed by the name without the F32 or F64 type suffix
+	cm.countMetric(ctxt[:len(ctxt)-3], len(orig)*int(unsafe.Sizeof(locPair[T]{})))
+	defer cm.enterCollective()()
+	n := len(orig)
+	isTo := toProc < 0 || cm.Rank() == toProc
+	var aerr error
+	if len(origIdx) != n || (isTo && (len(dest) != n || len(destIdx) != n)) {
+		aerr = errorf("mpi.%s: all slices must have the same length: %d", ctxt, n)
+	}
+	if n == 0 {
+		return aerr
+	}
+	sp := make([]locPair[T], n)
+	for i, v := range orig {
+		sp[i].val = v
+		if i >= len(origIdx) {
+			continue
+		}
+		idx := origIdx[i]
+		if idx < math.MinInt32 || idx > math.MaxInt32 {
+			if aerr == nil {
+				aerr = errorf("mpi.%s: index %d at %d is outside the 32bit int range", ctxt, idx, i)
+			}
+			continue
+		}
+		sp[i].idx = int32(idx)
+	}
+	var dp []locPair[T]
+	if isTo {
+		dp = make([]locPair[T], n)
+	}
+	var err error
+	if toProc < 0 {
+		err = Error(C.MPI_Allreduce(bufPtr(sp), bufPtr(dp), C.int(n), dt, op.ToC(), cm.comm), ctxt)
+	} else {
+		err = Error(C.MPI_Reduce(bufPtr(sp), bufPtr(dp), C.int(n), dt, op.ToC(), C.int(toProc), cm.comm), ctxt)
+	}
+	if aerr != nil {
+		return aerr
+	}
+	for i, p := range dp {
+		dest[i] = p.val
+		destIdx[i] = int(p.idx)
+	}
+	return err
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// LocalRanks returns the ranks of the procs in this communicator
+// (world ranks for the World communicator) that are on the same physical
+// host as this proc, in increasing order, e.g., for hierarchical collectives
+// or for allocating per-node resources such as GPUs.
+// It is computed on the first call, which must be made on all procs,
+// using a shared-memory split of the communicator, and cached after that.
+func (cm *Comm) LocalRanks() ([]int, error) {
+	if cm.localRanks != nil {
+		return cm.localRanks, nil
+	}
+	if cm.Size() == 1 {
+		cm.localRanks = []int{cm.Rank()}
+		return cm.localRanks, nil
+	}
+	lc, err := cm.splitTypeShared()
+	if err != nil {
+		return nil, err
+	}
+	defer lc.Free()
+	lr := make([]int, lc.Size())
+	if err := lc.AllGatherInt(lr, []int{cm.Rank()}); err != nil {
+		return nil, err
+	}
+	cm.localRanks = lr
+	return lr, nil
+}
+
+// LocalLeader returns the lowest rank among the LocalRanks on this host,
+// which is the designated leader for the node, e.g., for the proc that
+// participates in the across-node level of a hierarchical collective.
+// The first call to either method must be made on all procs.
+// Returns -1 if the LocalRanks could not be determined.
+func (cm *Comm) LocalLeader() int {
+	lr, err := cm.LocalRanks()
+	if err != nil || len(lr) == 0 {
+		return -1
+	}
+	return lr[0]
+}
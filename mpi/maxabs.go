@@ -0,0 +1,99 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+
+package mpi
+
+/*
+#cgo pkg-config: ompi
+#include "mpi.h"
+
+extern MPI_Datatype COMPLEX128;
+extern MPI_Datatype COMPLEX64;
+
+static void maxAbsC128(void *in, void *inout, int *len, MPI_Datatype *dt) {
+	double *a = (double *)in;
+	double *b = (double *)inout;
+	for (int i = 0; i < 2 * *len; i += 2) {
+		if (a[i]*a[i] + a[i+1]*a[i+1] > b[i]*b[i] + b[i+1]*b[i+1]) {
+			b[i] = a[i];
+			b[i+1] = a[i+1];
+		}
+	}
+}
+
+static void maxAbsC64(void *in, void *inout, int *len, MPI_Datatype *dt) {
+	float *a = (float *)in;
+	float *b = (float *)inout;
+	for (int i = 0; i < 2 * *len; i += 2) {
+		if (a[i]*a[i] + a[i+1]*a[i+1] > b[i]*b[i] + b[i+1]*b[i+1]) {
+			b[i] = a[i];
+			b[i+1] = a[i+1];
+		}
+	}
+}
+
+static int createMaxAbsOps(MPI_Op *c128, MPI_Op *c64) {
+	int ec = MPI_Op_create(maxAbsC128, 1, c128);
+	if (ec != MPI_SUCCESS) {
+		return ec;
+	}
+	return MPI_Op_create(maxAbsC64, 1, c64);
+}
+*/
+import "C"
+
+import (
+	"sync"
+	"unsafe"
+)
+
+var (
+	maxAbsOnce            sync.Once
+	maxAbsErr             error
+	maxAbsC128, maxAbsC64 C.MPI_Op
+)
+
+// maxAbsOps creates the max-by-magnitude ops the first time it is called.
+func maxAbsOps() error {
+	maxAbsOnce.Do(func() {
+		maxAbsErr = Error(C.createMaxAbsOps(&maxAbsC128, &maxAbsC64), "Op_create")
+	})
+	return maxAbsErr
+}
+
+// AllReduceC128MaxAbs reduces the values in buf across all procs, in place,
+// keeping the value with the largest magnitude (absolute value) for each element,
+// using a custom MPI operation.  OpMax and OpMin are not defined by MPI
+// for complex types, so this is the correct substitute for finding the maximum.
+// In case of equal magnitudes, any one of the values may be kept.
+func (cm *Comm) AllReduceC128MaxAbs(buf []complex128) error {
+	cm.countMetric("AllReduceMaxAbs", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	if err := maxAbsOps(); err != nil {
+		return err
+	}
+	return Error(C.MPI_Allreduce(C.MPI_IN_PLACE, bufPtr(buf), C.int(len(buf)), C.COMPLEX128, maxAbsC128, cm.comm), "AllReduceC128MaxAbs")
+}
+
+// AllReduceC64MaxAbs reduces the values in buf across all procs, in place,
+// keeping the value with the largest magnitude (absolute value) for each element,
+// using a custom MPI operation.  OpMax and OpMin are not defined by MPI
+// for complex types, so this is the correct substitute for finding the maximum.
+// In case of equal magnitudes, any one of the values may be kept.
+func (cm *Comm) AllReduceC64MaxAbs(buf []complex64) error {
+	cm.countMetric("AllReduceMaxAbs", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	if err := maxAbsOps(); err != nil {
+		return err
+	}
+	return Error(C.MPI_Allreduce(C.MPI_IN_PLACE, bufPtr(buf), C.int(len(buf)), C.COMPLEX64, maxAbsC64, cm.comm), "AllReduceC64MaxAbs")
+}
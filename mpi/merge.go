@@ -0,0 +1,105 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import "container/heap"
+
+// MergeSortedF32 merges the values from each proc, which must be sorted in
+// ascending order, along with their corresponding indices, into one sorted list
+// on the Root proc, which is returned there (and nil on other procs).
+// Each proc can have a different number of values.  Values that are equal
+// are ordered by proc rank.  For a distributed top-k selection, each proc
+// passes its own sorted top-k values, and the first k merged values are the
+// global top-k (use negated values for descending order).
+func (cm *Comm) MergeSortedF32(values []float32, indices []int) (mergedVals []float32, mergedIdx []int, err error) {
+	if len(indices) != len(values) {
+		return nil, nil, errorf("mpi.MergeSortedF32: len(indices) %d != len(values) %d", len(indices), len(values))
+	}
+	np := cm.Size()
+	if np == 1 {
+		mergedVals = append([]float32(nil), values...)
+		mergedIdx = append([]int(nil), indices...)
+		return
+	}
+	isRoot := cm.Rank() == Root
+	counts := make([]int, np)
+	err = cm.GatherInt(Root, counts, []int{len(values)})
+	if err != nil {
+		return
+	}
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	var allv []float32
+	var alli []int
+	if isRoot {
+		allv = make([]float32, total)
+		alli = make([]int, total)
+	}
+	err = cm.GathervF32(Root, allv, values, counts, nil)
+	if err != nil {
+		return
+	}
+	err = cm.GathervInt(Root, alli, indices, counts, nil)
+	if err != nil || !isRoot {
+		return
+	}
+	mh := &mergeHeap{vals: allv}
+	displs, _ := Displacements(counts)
+	for p, c := range counts {
+		if c > 0 {
+			mh.heads = append(mh.heads, mergeHead{pos: displs[p], end: displs[p] + c})
+		}
+	}
+	heap.Init(mh)
+	mergedVals = make([]float32, 0, total)
+	mergedIdx = make([]int, 0, total)
+	for mh.Len() > 0 {
+		hd := &mh.heads[0]
+		mergedVals = append(mergedVals, allv[hd.pos])
+		mergedIdx = append(mergedIdx, alli[hd.pos])
+		hd.pos++
+		if hd.pos == hd.end {
+			heap.Pop(mh)
+		} else {
+			heap.Fix(mh, 0)
+		}
+	}
+	return
+}
+
+// mergeHead is the current position in the sorted values from one proc.
+type mergeHead struct {
+	pos, end int
+}
+
+// mergeHeap is a min-heap of the current values from each proc,
+// for k-way merging.
+type mergeHeap struct {
+	vals  []float32
+	heads []mergeHead
+}
+
+func (mh *mergeHeap) Len() int { return len(mh.heads) }
+
+func (mh *mergeHeap) Less(i, j int) bool {
+	a, b := mh.heads[i].pos, mh.heads[j].pos
+	if mh.vals[a] == mh.vals[b] {
+		return a < b // rank order
+	}
+	return mh.vals[a] < mh.vals[b]
+}
+
+func (mh *mergeHeap) Swap(i, j int) { mh.heads[i], mh.heads[j] = mh.heads[j], mh.heads[i] }
+
+func (mh *mergeHeap) Push(x any) { mh.heads = append(mh.heads, x.(mergeHead)) }
+
+func (mh *mergeHeap) Pop() any {
+	n := len(mh.heads)
+	hd := mh.heads[n-1]
+	mh.heads = mh.heads[:n-1]
+	return hd
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import (
+	"maps"
+	"sync"
+)
+
+// OpMetrics are the counts for one type of communication operation.
+type OpMetrics struct {
+
+	// Calls is the number of calls made.
+	Calls int64
+
+	// Bytes is the total size of the data passed by this proc in the calls,
+	// e.g., the values sent for Send, Bcast, Gather and AllGather,
+	// the values received for Recv and Scatter, and the values reduced
+	// for Reduce and AllReduce.
+	Bytes int64
+}
+
+// Metrics are the counts of the calls and bytes of each type of
+// communication operation made on a Comm, as recorded when EnableMetrics
+// is on, e.g., for capacity planning.
+type Metrics struct {
+
+	// Ops are the counts for each type of operation, keyed by the
+	// operation name without the type suffix, e.g., Send, Bcast,
+	// AllReduce, Gather, and Barrier.
+	Ops map[string]OpMetrics
+}
+
+// metricsState holds the Metrics for a Comm, which can be updated
+// from multiple goroutines.
+type metricsState struct {
+	mu  sync.Mutex
+	ops map[string]OpMetrics
+}
+
+// EnableMetrics turns on recording of the Metrics for the communication
+// operations made on this Comm by this proc, starting from zero counts,
+// which can be read with MetricsSnapshot.  The recording is off by default,
+// at which point there is no overhead.  Counts are only recorded for the
+// operations that actually communicate, so they are always empty when
+// not built with mpi.
+func (cm *Comm) EnableMetrics() {
+	cm.metrics = &metricsState{ops: make(map[string]OpMetrics)}
+}
+
+// MetricsSnapshot returns a copy of the current Metrics for this Comm,
+// which are empty if EnableMetrics has not been called.
+func (cm *Comm) MetricsSnapshot() Metrics {
+	ms := cm.metrics
+	if ms == nil {
+		return Metrics{Ops: map[string]OpMetrics{}}
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return Metrics{Ops: maps.Clone(ms.ops)}
+}
+
+// countMetric records a call of given operation, passing given number of
+// bytes, if EnableMetrics is on.
+func (cm *Comm) countMetric(op string, bytes int) {
+	ms := cm.metrics
+	if ms == nil {
+		return
+	}
+	ms.mu.Lock()
+	om := ms.ops[op]
+	om.Calls++
+	om.Bytes += int64(bytes)
+	ms.ops[op] = om
+	ms.mu.Unlock()
+}
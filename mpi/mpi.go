@@ -14,6 +14,7 @@ package mpi
 
 /*
 #cgo pkg-config: ompi
+#include <stdlib.h>
 #include "mpi.h"
 
 MPI_Comm     World     = MPI_COMM_WORLD;
@@ -23,12 +24,22 @@ import "C"
 import (
 	"fmt"
 	"log"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"unsafe"
 )
 
 // set LogErrors to control whether MPI errors are automatically logged or not
 var LogErrors = true
 
+// set CheckABIAtInit to have Init check that the MPI run time library
+// matches the headers used at compile time, using CheckABI
+var CheckABIAtInit = false
+
+// threadMultiple is set when MPI has been initialized with MPI_THREAD_MULTIPLE
+var threadMultiple bool
+
 // Error takes an MPI error code and returns an appropriate error
 // value -- either nil if no error, or the MPI error message
 // with given context
@@ -50,6 +61,9 @@ func Error(ec C.int, ctxt string) error {
 }
 
 // Op is an aggregation operation: Sum, Min, Max, etc
+// OpMax and OpMin are not defined for complex types (C64, C128):
+// use AllReduceC128MaxAbs or AllReduceC64MaxAbs instead.
+// Custom float32 operations can be created with NewOp.
 type Op int
 
 const (
@@ -62,9 +76,17 @@ const (
 	OpBAND // bitwise AND
 	OpBOR  // bitwise OR
 
+	// OpMaxLoc and OpMinLoc keep the maximum or minimum value along with
+	// its index, and are only valid for the MaxLoc and MinLoc methods,
+	// which reduce value-index pairs.
+	OpMaxLoc
+	OpMinLoc
 )
 
 func (op Op) ToC() C.MPI_Op {
+	if op >= opUser {
+		return userOpC(op)
+	}
 	switch op {
 	case OpSum:
 		return C.MPI_SUM
@@ -82,6 +104,10 @@ func (op Op) ToC() C.MPI_Op {
 		return C.MPI_BAND
 	case OpBOR:
 		return C.MPI_BOR
+	case OpMaxLoc:
+		return C.MPI_MAXLOC
+	case OpMinLoc:
+		return C.MPI_MINLOC
 	}
 	return C.MPI_SUM
 }
@@ -89,6 +115,14 @@ func (op Op) ToC() C.MPI_Op {
 const (
 	// Root is the rank 0 node -- it is more semantic to use this
 	Root int = 0
+
+	// AnySource can be passed as the fmProc to Recv methods
+	// to receive a message from any proc
+	AnySource int = C.MPI_ANY_SOURCE
+
+	// AnyTag can be passed as the tag to Recv methods
+	// to receive a message with any tag
+	AnyTag int = C.MPI_ANY_TAG
 )
 
 // IsOn tells whether MPI is on or not
@@ -106,12 +140,21 @@ func IsOn() bool {
 // Init initialises MPI
 func Init() {
 	C.MPI_Init(nil, nil)
+	checkTypeSizes()
+	if CheckABIAtInit {
+		CheckABI()
+	}
 }
 
 // InitThreadSafe initialises MPI thread safe
 func InitThreadSafe() error {
 	var r int32
 	C.MPI_Init_thread(nil, nil, C.MPI_THREAD_MULTIPLE, (*C.int)(unsafe.Pointer(&r)))
+	checkTypeSizes()
+	if CheckABIAtInit {
+		CheckABI()
+	}
+	threadMultiple = r == C.MPI_THREAD_MULTIPLE
 	if r != C.MPI_THREAD_MULTIPLE {
 		return fmt.Errorf("MPI_THREAD_MULTIPLE can't be set: got %d", r)
 	}
@@ -145,12 +188,68 @@ func WorldSize() (size int) {
 	return int(s)
 }
 
+// Wtime returns the elapsed wall-clock time in seconds since an arbitrary
+// point in the past, which is fixed for the life of the process.
+// Use differences between calls to measure elapsed time.
+func Wtime() float64 {
+	return float64(C.MPI_Wtime())
+}
+
+// Wtick returns the resolution of Wtime in seconds, i.e., the time
+// between successive ticks of the clock.
+func Wtick() float64 {
+	return float64(C.MPI_Wtick())
+}
+
+// ProcessorName returns the name of the processor (host) that this proc
+// is running on, as reported by MPI, e.g., for logging the placement
+// of procs on hosts.
+func ProcessorName() string {
+	var buf [C.MPI_MAX_PROCESSOR_NAME]C.char
+	var n C.int
+	C.MPI_Get_processor_name(&buf[0], &n)
+	return strings.TrimSpace(C.GoStringN(&buf[0], n))
+}
+
 // Comm is the MPI communicator -- all MPI communication operates as methods
 // on this struct.  It holds the MPI_Comm communicator and MPI_Group for
 // sub-World group communication.
 type Comm struct {
 	comm  C.MPI_Comm
 	group C.MPI_Group
+	name  string
+
+	// rank and size are cached, once size is > 0
+	rank, size int
+
+	// subs are cached sub-communicators, keyed by ranks
+	subs map[string]*Comm
+
+	// progress is the state for ProgressSum
+	progress *progressState
+
+	// heartbeat is the state for Heartbeat
+	heartbeat *heartbeatState
+
+	// barrierDiag is the state for BarrierDiag
+	barrierDiag *barrierDiagState
+
+	// localRanks are the ranks on the local host, cached by LocalRanks
+	localRanks []int
+
+	// metrics are the communication counts, when EnableMetrics is on
+	metrics *metricsState
+
+	// scratchF32 is the buffer returned by ScratchF32
+	scratchF32 []float32
+
+	// nCollectives is the number of collective calls made,
+	// when TraceCollectives is on
+	nCollectives int
+
+	// inCollective is the id of the goroutine in a collective call,
+	// for checking in the mpidebug build
+	inCollective atomic.Int64
 }
 
 // NewComm creates a new communicator.
@@ -174,18 +273,203 @@ func NewComm(ranks []int) (*Comm, error) {
 	return cm, Error(C.MPI_Comm_create(C.World, cm.group, &cm.comm), "Comm_create")
 }
 
-// Rank returns the rank/ID for this proc
+// dup returns a duplicate of this communicator, with the same procs
+// but a separate communication space, which must be called on all procs.
+func (cm *Comm) dup() (*Comm, error) {
+	nc := &Comm{}
+	err := Error(C.MPI_Comm_dup(cm.comm, &nc.comm), "Comm_dup")
+	if err != nil {
+		return nil, err
+	}
+	return nc, Error(C.MPI_Comm_group(nc.comm, &nc.group), "Comm_group")
+}
+
+// newSubComm creates a new communicator for given ranks within this
+// communicator, which must be called on all procs in this communicator.
+// Returns nil for procs that are not in ranks.
+func (cm *Comm) newSubComm(ranks []int) (*Comm, error) {
+	sc := &Comm{}
+	rs := cInts(ranks)
+	err := Error(C.MPI_Group_incl(cm.group, C.int(len(rs)), &rs[0], &sc.group), "Group_incl")
+	if err != nil {
+		return nil, err
+	}
+	err = Error(C.MPI_Comm_create(cm.comm, sc.group, &sc.comm), "Comm_create")
+	if err != nil {
+		return nil, err
+	}
+	if sc.comm == C.MPI_COMM_NULL {
+		C.MPI_Group_free(&sc.group)
+		return nil, nil
+	}
+	return sc, nil
+}
+
+// ExcludeRanks returns a new communicator containing all the procs in this
+// one except those with given ranks (world ranks for the World communicator),
+// e.g., to continue in a degraded mode without a proc known to be bad.
+// This complements NewComm, which takes the ranks to include.
+// It must be called on all procs in this communicator, with the same ranks,
+// and returns nil for the excluded procs.  Call Free when done with it.
+func (cm *Comm) ExcludeRanks(ranks []int) (*Comm, error) {
+	nc := &Comm{}
+	var rp *C.int
+	rs := cInts(ranks)
+	if len(rs) > 0 {
+		rp = &rs[0]
+	}
+	err := Error(C.MPI_Group_excl(cm.group, C.int(len(rs)), rp, &nc.group), "Group_excl")
+	if err != nil {
+		return nil, err
+	}
+	err = Error(C.MPI_Comm_create(cm.comm, nc.group, &nc.comm), "Comm_create")
+	if err != nil {
+		return nil, err
+	}
+	if nc.comm == C.MPI_COMM_NULL {
+		C.MPI_Group_free(&nc.group)
+		return nil, nil
+	}
+	return nc, nil
+}
+
+// Free releases the MPI resources held by this communicator, including
+// any cached sub-communicators and the private communicators used by
+// ProgressSum, Heartbeat and BarrierDiag, and sets its handles to null, so that it
+// must not be used after this.  It must be called on all procs in the
+// communicator, after any pending operations have completed.
+// The World MPI communicator itself must not be freed, so it is not,
+// but its group is, and this Comm can no longer be used.
+func (cm *Comm) Free() error {
+	keys := make([]string, 0, len(cm.subs))
+	for k := range cm.subs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys) // same order on all procs
+	for _, k := range keys {
+		if sc := cm.subs[k]; sc != nil {
+			if err := sc.Free(); err != nil {
+				return err
+			}
+		}
+	}
+	cm.subs = nil
+	if cm.progress != nil {
+		if err := cm.progress.comm.Free(); err != nil {
+			return err
+		}
+		cm.progress = nil
+	}
+	if cm.heartbeat != nil {
+		if err := cm.heartbeat.comm.Free(); err != nil {
+			return err
+		}
+		cm.heartbeat = nil
+	}
+	if cm.barrierDiag != nil {
+		if err := cm.barrierDiag.comm.Free(); err != nil {
+			return err
+		}
+		cm.barrierDiag = nil
+	}
+	if cm.comm == C.World {
+		cm.comm = C.MPI_COMM_NULL
+	} else if cm.comm != C.MPI_COMM_NULL {
+		if err := Error(C.MPI_Comm_free(&cm.comm), "Comm_free"); err != nil {
+			return err
+		}
+	}
+	if cm.group == C.MPI_GROUP_NULL {
+		return nil
+	}
+	return Error(C.MPI_Group_free(&cm.group), "Group_free")
+}
+
+// split returns a new communicator containing the procs in this one that
+// pass the same color, ordered by key, which must be called on all procs.
+func (cm *Comm) split(color, key int) (*Comm, error) {
+	nc := &Comm{}
+	err := Error(C.MPI_Comm_split(cm.comm, C.int(color), C.int(key), &nc.comm), "Comm_split")
+	if err != nil {
+		return nil, err
+	}
+	return nc, Error(C.MPI_Comm_group(nc.comm, &nc.group), "Comm_group")
+}
+
+// splitTypeShared returns a new communicator containing the procs in this
+// one that are on the same host as this proc, sharing memory, which must be
+// called on all procs.  Ranks in the new communicator are in the same order
+// as in this one.
+func (cm *Comm) splitTypeShared() (*Comm, error) {
+	nc := &Comm{}
+	err := Error(C.MPI_Comm_split_type(cm.comm, C.MPI_COMM_TYPE_SHARED, C.int(cm.Rank()), C.MPI_INFO_NULL, &nc.comm), "Comm_split_type")
+	if err != nil {
+		return nil, err
+	}
+	return nc, Error(C.MPI_Comm_group(nc.comm, &nc.group), "Comm_group")
+}
+
+// SplitTypeNUMA returns a new communicator containing the procs in this one
+// that share the same NUMA domain (e.g., CPU socket) as this proc, which must
+// be called on all procs.  Ranks in the new communicator are in the same order
+// as in this one.  This is useful for the innermost tier of hierarchical
+// reductions (NUMA domain, then node, then across nodes) on large multi-socket
+// nodes.  It uses the OpenMPI-specific OMPI_COMM_TYPE_NUMA split type,
+// and returns a nil Comm and an error if this is not supported.
+func (cm *Comm) SplitTypeNUMA() (*Comm, error) {
+	nc := &Comm{}
+	err := Error(C.MPI_Comm_split_type(cm.comm, C.int(C.OMPI_COMM_TYPE_NUMA), C.int(cm.Rank()), C.MPI_INFO_NULL, &nc.comm), "Comm_split_type")
+	if err != nil {
+		return nil, err
+	}
+	if nc.comm == C.MPI_COMM_NULL {
+		return nil, errorf("mpi.SplitTypeNUMA: NUMA split type is not supported by this MPI")
+	}
+	return nc, Error(C.MPI_Comm_group(nc.comm, &nc.group), "Comm_group")
+}
+
+// Rank returns the rank/ID for this proc.
+// It is cached after the first call: see Refresh.
 func (cm *Comm) Rank() (rank int) {
-	var r int32
-	C.MPI_Comm_rank(cm.comm, (*C.int)(unsafe.Pointer(&r)))
-	return int(r)
+	if cm.size == 0 {
+		cm.Refresh()
+	}
+	return cm.rank
 }
 
-// Size returns the number of procs in this communicator
+// Size returns the number of procs in this communicator.
+// It is cached after the first call: see Refresh.
 func (cm *Comm) Size() (size int) {
-	var s int32
+	if cm.size == 0 {
+		cm.Refresh()
+	}
+	return cm.size
+}
+
+// Refresh updates the cached Rank and Size of this proc in this communicator,
+// from MPI, and clears the cached LocalRanks.  These do not change over the
+// life of a communicator, so this is only needed in the rare case that the
+// underlying MPI communicator is replaced (e.g., by dynamic process management).
+func (cm *Comm) Refresh() {
+	var r, s int32
+	C.MPI_Comm_rank(cm.comm, (*C.int)(unsafe.Pointer(&r)))
 	C.MPI_Comm_size(cm.comm, (*C.int)(unsafe.Pointer(&s)))
-	return int(s)
+	cm.rank, cm.size = int(r), int(s)
+	cm.localRanks = nil
+}
+
+// SetName sets the name of this communicator, which is used to identify
+// it in log messages (see Logf), and in MPI error messages and tools.
+func (cm *Comm) SetName(name string) error {
+	cm.name = name
+	cs := C.CString(name)
+	defer C.free(unsafe.Pointer(cs))
+	return Error(C.MPI_Comm_set_name(cm.comm, cs), "Comm_set_name")
+}
+
+// Name returns the name of this communicator, as set by SetName
+func (cm *Comm) Name() string {
+	return cm.name
 }
 
 // Abort aborts MPI
@@ -193,7 +477,115 @@ func (cm *Comm) Abort() error {
 	return Error(C.MPI_Abort(cm.comm, 0), "Abort")
 }
 
+// abort aborts MPI with given exit code
+func (cm *Comm) abort(code int) error {
+	return Error(C.MPI_Abort(cm.comm, C.int(code)), "Abort")
+}
+
 // Barrier forces synchronisation
 func (cm *Comm) Barrier() error {
+	cm.countMetric("Barrier", 0)
+	defer cm.enterCollective()()
 	return Error(C.MPI_Barrier(cm.comm), "Barrier")
 }
+
+// IBarrier starts a non-blocking barrier, returning a Request that
+// completes when all procs in the communicator have entered the barrier.
+func (cm *Comm) IBarrier() (*Request, error) {
+	cm.countMetric("IBarrier", 0)
+	defer cm.enterCollective()()
+	r := newRequest(nil)
+	return r, Error(C.MPI_Ibarrier(cm.comm, &r.req), "Ibarrier")
+}
+
+// checkOp checks that all procs in this communicator pass the same op
+// to the collective call of given name, if CheckOps is on, returning
+// an error on all procs if they differ.
+func (cm *Comm) checkOp(op Op, name string) error {
+	if !CheckOps || cm.Size() == 1 {
+		return nil
+	}
+	ov := []int32{int32(op), -int32(op)}
+	ext := []int32{0, 0}
+	err := Error(C.MPI_Allreduce(unsafe.Pointer(&ov[0]), unsafe.Pointer(&ext[0]), 2, C.MPI_INT, C.MPI_MAX, cm.comm), name)
+	if err != nil {
+		return err
+	}
+	if ext[0] != -ext[1] {
+		return errorf("mpi.%s: CheckOps: procs passed different Op values: min %d != max %d (this proc: %d: %d)", name, -ext[1], ext[0], cm.Rank(), op)
+	}
+	return nil
+}
+
+// rootCheck broadcasts whether err, from checking arguments that are only
+// used on proc root, is nil, before a collective call, so that all procs
+// return an error together, instead of the other procs blocking forever
+// in the collective call when root returns early.  err is ignored on all
+// other procs, which return an error naming the collective call.
+func (cm *Comm) rootCheck(root int, err error, name string) error {
+	if cm.Size() == 1 {
+		return err
+	}
+	bad := C.int(0)
+	if cm.Rank() == root && err != nil {
+		bad = 1
+	}
+	berr := Error(C.MPI_Bcast(unsafe.Pointer(&bad), 1, C.MPI_INT, C.int(root), cm.comm), name)
+	if berr != nil {
+		return berr
+	}
+	if bad == 0 {
+		return nil
+	}
+	if cm.Rank() == root {
+		return err
+	}
+	return errorf("mpi.%s: invalid arguments on proc %d", name, root)
+}
+
+// allCheck reduces whether err, from checking arguments that can differ
+// across procs, is nil on all procs, before a collective call, so that
+// all procs return an error together, as in rootCheck.  Procs where err
+// is nil return an error naming the collective call.
+func (cm *Comm) allCheck(err error, name string) error {
+	if cm.Size() == 1 {
+		return err
+	}
+	bad := C.int(0)
+	if err != nil {
+		bad = 1
+	}
+	anyBad := C.int(0)
+	aerr := Error(C.MPI_Allreduce(unsafe.Pointer(&bad), unsafe.Pointer(&anyBad), 1, C.MPI_INT, C.MPI_MAX, cm.comm), name)
+	if aerr != nil {
+		return aerr
+	}
+	if anyBad == 0 {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return errorf("mpi.%s: invalid arguments on another proc", name)
+}
+
+// bufPtr returns the pointer to the start of given buffer for passing to MPI,
+// or nil if it is empty, which is valid for MPI calls with a count of 0,
+// and for root-only buffers such as dest in Reduce and Gather
+// on the other procs, instead of panicking with an index out of range.
+func bufPtr[T any](buf []T) unsafe.Pointer {
+	if len(buf) == 0 {
+		return nil
+	}
+	return unsafe.Pointer(&buf[0])
+}
+
+// cInts converts given ints to C ints, for passing arrays of counts
+// and displacements to MPI.
+func cInts(vals []int) []C.int {
+	cv := make([]C.int, len(vals))
+	for i, v := range vals {
+		cv[i] = C.int(v)
+	}
+	return cv
+}
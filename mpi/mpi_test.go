@@ -0,0 +1,155 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+
+package mpi
+
+import (
+	"os"
+	"testing"
+)
+
+// these tests are run under mpirun, e.g.:
+// mpirun -np 4 go test -tags mpi ./mpi
+
+func TestMain(m *testing.M) {
+	Init()
+	code := m.Run()
+	Finalize()
+	os.Exit(code)
+}
+
+// worldComm returns the World communicator for tests.
+func worldComm(t *testing.T) *Comm {
+	t.Helper()
+	cm, err := NewComm(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cm
+}
+
+func TestAllReduceStridedF32(t *testing.T) {
+	cm := worldComm(t)
+	rank := cm.Rank()
+	count, stride, offset := 5, 3, 1
+	n := offset + count*stride
+	orig := make([]float32, n)
+	for i := range orig {
+		orig[i] = float32(rank*100 + i)
+	}
+	dest := make([]float32, n)
+	want := make([]float32, n)
+	for i := range dest {
+		dest[i] = -1
+		want[i] = -1
+	}
+	if err := cm.AllReduceStridedF32(OpSum, dest, orig, count, stride, offset); err != nil {
+		t.Fatal(err)
+	}
+
+	// manual version: copy to contiguous, AllReduceF32, and scatter back
+	co := make([]float32, count)
+	for i := range co {
+		co[i] = orig[offset+i*stride]
+	}
+	cd := make([]float32, count)
+	if err := cm.AllReduceF32(OpSum, cd, co); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range cd {
+		want[offset+i*stride] = v
+	}
+	for i := range dest {
+		if dest[i] != want[i] {
+			t.Errorf("proc %d: index %d: got %g, want %g", rank, i, dest[i], want[i])
+		}
+	}
+}
+
+func TestReduceNilDest(t *testing.T) {
+	cm := worldComm(t)
+	rank, np := cm.Rank(), cm.Size()
+	orig := []float64{float64(rank), 1}
+	var dest []float64 // nil on all procs except Root
+	if rank == Root {
+		dest = make([]float64, len(orig))
+	}
+	if err := cm.ReduceF64(Root, OpSum, dest, orig); err != nil {
+		t.Fatalf("proc %d: %v", rank, err)
+	}
+	if rank == Root {
+		if want := float64(np * (np - 1) / 2); dest[0] != want || dest[1] != float64(np) {
+			t.Errorf("got %v, want [%g %d]", dest, want, np)
+		}
+	}
+
+	// a short dest on Root is an error there, without blocking the other procs
+	err := cm.ReduceF64(Root, OpSum, nil, orig)
+	if rank == Root && err == nil {
+		t.Errorf("expected error for nil dest on Root")
+	}
+	if rank != Root && err != nil {
+		t.Errorf("proc %d: %v", rank, err)
+	}
+}
+
+func TestIrecvStatus(t *testing.T) {
+	cm := worldComm(t)
+	rank, np := cm.Rank(), cm.Size()
+	if rank != Root {
+		// each proc sends rank values with its rank as the tag
+		if err := cm.SendInt(Root, rank, make([]int, rank)); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	reqs := make([]*Request, np-1)
+	for i := range reqs {
+		r, err := cm.IrecvInt(AnySource, AnyTag, make([]int, np))
+		if err != nil {
+			t.Fatal(err)
+		}
+		reqs[i] = r
+	}
+	if err := Waitall(reqs); err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[int]bool)
+	for _, r := range reqs {
+		st := r.Status()
+		if st.Error != nil {
+			t.Errorf("proc %d: %v", st.Source, st.Error)
+		}
+		if st.Tag != st.Source || st.Count != st.Source {
+			t.Errorf("proc %d: got tag %d, count %d, want %d", st.Source, st.Tag, st.Count, st.Source)
+		}
+		seen[st.Source] = true
+	}
+	if len(seen) != np-1 {
+		t.Errorf("got messages from procs %v, want all %d other procs", seen, np-1)
+	}
+}
+
+func TestParseLibraryVersion(t *testing.T) {
+	tests := []struct {
+		lib          string
+		major, minor int
+		ok           bool
+	}{
+		{"Open MPI v5.0.1, package: Open MPI", 5, 0, true},
+		{"Open MPI v5.0.10, package: Open MPI", 5, 0, true},
+		{"Open MPI v4.1.2", 4, 1, true},
+		{"Open MPI v5.1rc1, package: Open MPI", 5, 1, true},
+		{"MPICH Version: 4.1", 0, 0, false},
+		{"Open MPI v5", 0, 0, false},
+	}
+	for _, tt := range tests {
+		major, minor, ok := parseLibraryVersion(tt.lib)
+		if major != tt.major || minor != tt.minor || ok != tt.ok {
+			t.Errorf("%q: got %d.%d %v, want %d.%d %v", tt.lib, major, minor, ok, tt.major, tt.minor, tt.ok)
+		}
+	}
+}
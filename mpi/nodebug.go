@@ -0,0 +1,19 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi && !mpidebug
+
+package mpi
+
+// checkTypeSizes is only active in the mpidebug build.
+func checkTypeSizes() {
+}
+
+// enterCollective is called at the start of each collective call, and returns
+// the function to call at the end of it.  Checking for concurrent collective
+// calls is only active in the mpidebug build.
+func (cm *Comm) enterCollective() func() {
+	cm.traceCollective()
+	return nopExit
+}
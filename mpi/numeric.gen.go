@@ -15,6 +15,7 @@ package mpi
 MPI_Datatype FLOAT64   = MPI_DOUBLE;
 MPI_Datatype FLOAT32   = MPI_FLOAT;
 MPI_Datatype INT64     = MPI_LONG;
+MPI_Datatype GOINT     = MPI_INT64_T;
 MPI_Datatype UINT64    = MPI_UNSIGNED_LONG;
 MPI_Datatype INT32     = MPI_INT;
 MPI_Datatype UINT32    = MPI_UNSIGNED;
@@ -31,1068 +32,6159 @@ import (
 	"unsafe"
 )
 
+func init() {
+	// Go int is 32 bits on 32 bit platforms, so it must use the matching
+	// MPI datatype, otherwise the transfer sizes would be wrong.
+	if unsafe.Sizeof(int(0)) == 4 {
+		C.GOINT = C.MPI_INT32_T
+	}
+}
+
+// typeSize records the size of a Go element type, and the MPI datatype
+// used to transfer it.
+type typeSize struct {
+	name string
+	dt   C.MPI_Datatype
+	size uintptr
+}
+
+// typeSizes returns the typeSize for each of the element types,
+// for checking in the mpidebug build.
+func typeSizes() []typeSize {
+	return []typeSize{
+		{"F64", C.FLOAT64, unsafe.Sizeof(float64(0))},
+		{"F32", C.FLOAT32, unsafe.Sizeof(float32(0))},
+		{"Int", C.GOINT, unsafe.Sizeof(int(0))},
+		{"I64", C.INT64, unsafe.Sizeof(int64(0))},
+		{"U64", C.UINT64, unsafe.Sizeof(uint64(0))},
+		{"I32", C.INT32, unsafe.Sizeof(int32(0))},
+		{"U32", C.UINT32, unsafe.Sizeof(uint32(0))},
+		{"I16", C.INT16, unsafe.Sizeof(int16(0))},
+		{"U16", C.UINT16, unsafe.Sizeof(uint16(0))},
+		{"I8", C.BYTE, unsafe.Sizeof(int8(0))},
+		{"U8", C.BYTE, unsafe.Sizeof(uint8(0))},
+		{"C128", C.COMPLEX128, unsafe.Sizeof(complex128(0))},
+		{"C64", C.COMPLEX64, unsafe.Sizeof(complex64(0))},
+	}
+}
+
 // SendF64 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendF64(toProc int, tag int, vals []float64) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Send", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Send(buf, C.int(len(vals)), C.FLOAT64, C.int(toProc), C.int(tag), cm.comm), "SendF64")
 }
 
-// Recv64F64 receives values from proc fmProc, using given unique tag identifier
+// RecvF64 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvF64(fmProc int, tag int, vals []float64) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Recv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.FLOAT64, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvF64")
 }
 
+// IsendF64 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendF64(toProc int, tag int, vals []float64) (*Request, error) {
+	cm.countMetric("Isend", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRequest(bufPtr(vals))
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Isend(buf, C.int(len(vals)), C.FLOAT64, C.int(toProc), C.int(tag), cm.comm, &r.req), "IsendF64")
+}
+
+// IrecvF64 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvF64(fmProc int, tag int, vals []float64) (*Request, error) {
+	cm.countMetric("Irecv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRecvRequest(bufPtr(vals), C.FLOAT64)
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Irecv(buf, C.int(len(vals)), C.FLOAT64, C.int(fmProc), C.int(tag), cm.comm, &r.req), "IrecvF64")
+}
+
 // BcastF64 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastF64(fmProc int, vals []float64) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Bcast", len(vals)*int(unsafe.Sizeof(vals[0])))
+	defer cm.enterCollective()()
+	buf := bufPtr(vals)
 	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.FLOAT64, C.int(fmProc), cm.comm), "BcastF64")
 }
 
 // ReduceF64 reduces all values across procs to toProc in orig to dest using given operation.
-// recvbuf is ignored on all procs except toProc.
+// If VerifyReduce is set, the result is checked against an AllReduce.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceF64(toProc int, op Op, dest, orig []float64) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Reduce", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ReduceF64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ReduceF64"); err != nil {
+		return err
+	}
+	isTo := cm.Rank() == toProc
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var derr error
+	if isTo {
+		if len(dest) < len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.ReduceF64: len(dest) %d < len(orig) %d", len(dest), len(orig))
+			recvbuf = bufPtr(make([]float64, len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(orig)), C.FLOAT64, op.ToC(), C.int(toProc), cm.comm), "ReduceF64")
+	if err == nil && VerifyReduce {
+		ver := make([]float64, len(orig))
+		err = Error(C.MPI_Allreduce(sendbuf, bufPtr(ver), C.int(len(orig)), C.FLOAT64, op.ToC(), cm.comm), "ReduceF64")
+		if err == nil && isTo && derr == nil {
+			err = verifyReduce(dest, ver, "ReduceF64")
+		}
+	}
+	if derr != nil {
+		return derr
 	}
-	return Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(dest)), C.FLOAT64, op.ToC(), C.int(toProc), cm.comm), "ReduceF64")
+	return err
 }
 
 // AllReduceF64 reduces all values across procs to all procs from orig into dest using given operation.
 // IMPORTANT: orig and dest must be different slices
 // To do an in-place operation, set orig to nil
 func (cm *Comm) AllReduceF64(op Op, dest, orig []float64) error {
+	cm.countMetric("AllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceF64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceF64"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
 	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+		sendbuf = bufPtr(orig)
 	} else {
 		sendbuf = C.MPI_IN_PLACE
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.FLOAT64, op.ToC(), cm.comm), "AllReduceF64")
 }
 
+// IAllReduceF64 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceF64(op Op, dest, orig []float64) (*Request, error) {
+	cm.countMetric("IAllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "IAllReduceF64"); err != nil {
+		return nil, err
+	}
+	if err := cm.checkOp(op, "IAllReduceF64"); err != nil {
+		return nil, err
+	}
+	r := newRequest(bufPtr(dest))
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = bufPtr(orig)
+		if sendbuf != nil {
+			r.pin.Pin(sendbuf)
+		}
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := bufPtr(dest)
+	return r, Error(C.MPI_Iallreduce(sendbuf, recvbuf, C.int(len(dest)), C.FLOAT64, op.ToC(), cm.comm, &r.req), "IAllReduceF64")
+}
+
+// ScanF64 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanF64(op Op, dest, orig []float64) error {
+	cm.countMetric("Scan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ScanF64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ScanF64"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ScanF64: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	return Error(C.MPI_Scan(bufPtr(orig), bufPtr(dest), C.int(len(orig)), C.FLOAT64, op.ToC(), cm.comm), "ScanF64")
+}
+
+// ExScanF64 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanF64(op Op, dest, orig []float64) error {
+	cm.countMetric("ExScan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ExScanF64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ExScanF64"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ExScanF64: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	recvbuf := bufPtr(dest)
+	if cm.Rank() == 0 {
+		recvbuf = bufPtr(make([]float64, len(orig)))
+	}
+	return Error(C.MPI_Exscan(bufPtr(orig), recvbuf, C.int(len(orig)), C.FLOAT64, op.ToC(), cm.comm), "ExScanF64")
+}
+
 // GatherF64 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
-// recvbuf is ignored on all procs except toProc.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherF64(toProc int, dest, orig []float64) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Gather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	var recvbuf unsafe.Pointer
+	var derr error
+	if cm.Rank() == toProc {
+		np := cm.Size()
+		if len(dest) < np*len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.GatherF64: len(dest) %d < number of procs %d * len(orig) %d", len(dest), np, len(orig))
+			recvbuf = bufPtr(make([]float64, np*len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.FLOAT64, recvbuf, C.int(len(orig)), C.FLOAT64, C.int(toProc), cm.comm), "GatherF64")
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+// GatherInPlaceF64 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceF64(toProc int, buf []float64) error {
+	cm.countMetric("GatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	isTo := cm.Rank() == toProc
+	np := cm.Size()
+	var err error
+	if isTo && len(buf)%np != 0 {
+		err = errorf("mpi.GatherInPlaceF64: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	if err = cm.rootCheck(toProc, err, "GatherInPlaceF64"); err != nil {
+		return err
+	}
+	ptr := bufPtr(buf)
+	if !isTo {
+		return Error(C.MPI_Gather(ptr, C.int(len(buf)), C.FLOAT64, nil, 0, C.FLOAT64, C.int(toProc), cm.comm), "GatherInPlaceF64")
+	}
+	n := len(buf) / np
+	return Error(C.MPI_Gather(C.MPI_IN_PLACE, 0, C.FLOAT64, ptr, C.int(n), C.FLOAT64, C.int(toProc), cm.comm), "GatherInPlaceF64")
+}
+
+// GathervF64 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervF64(toProc int, dest, orig []float64, counts, displs []int) error {
+	cm.countMetric("Gatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	isTo := cm.Rank() == toProc
+	var err error
+	if isTo {
+		np := cm.Size()
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.GathervF64: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[toProc] != len(orig):
+			err = errorf("mpi.GathervF64: counts[%d] %d is not equal to len(orig): %d", toProc, counts[toProc], len(orig))
+		default:
+			err = checkCounts("GathervF64", "dest", len(dest), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(toProc, err, "GathervF64"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var rc, rd *C.int
+	if isTo {
+		recvbuf = bufPtr(dest)
+		cc, cd := cInts(counts), cInts(displs)
+		rc, rd = &cc[0], &cd[0]
 	}
-	return Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.FLOAT64, recvbuf, C.int(len(orig)), C.FLOAT64, C.int(toProc), cm.comm), "GatherF64")
+	return Error(C.MPI_Gatherv(sendbuf, C.int(len(orig)), C.FLOAT64, recvbuf, rc, rd, C.FLOAT64, C.int(toProc), cm.comm), "GathervF64")
 }
 
 // AllGatherF64 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) AllGatherF64(dest, orig []float64) error {
-	sendbuf := unsafe.Pointer(&orig[0])
-	recvbuf := unsafe.Pointer(&dest[0])
+	cm.countMetric("AllGather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.FLOAT64, recvbuf, C.int(len(orig)), C.FLOAT64, cm.comm), "GatherF64")
 }
 
+// AllGathervF64 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervF64(dest, orig []float64, counts, displs []int) error {
+	cm.countMetric("AllGatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if displs == nil {
+		displs, _ = Displacements(counts)
+	}
+	// counts and displs are the same on all procs, so all procs return together.
+	if len(counts) != np || len(displs) != np {
+		return errorf("mpi.AllGathervF64: counts and displacements must have length equal to number of procs: %d", np)
+	}
+	if err := checkCounts("AllGathervF64", "dest", len(dest), counts, displs); err != nil {
+		return err
+	}
+	// but len(orig) is only known locally, so a mismatch is sent from
+	// scratch, to keep the other procs from blocking, and returned after.
+	rank := cm.Rank()
+	send := orig
+	var oerr error
+	if counts[rank] != len(orig) {
+		oerr = errorf("mpi.AllGathervF64: counts[%d] %d is not equal to len(orig): %d", rank, counts[rank], len(orig))
+		send = make([]float64, counts[rank])
+		copy(send, orig)
+	}
+	sendbuf := bufPtr(send)
+	recvbuf := bufPtr(dest)
+	cc, cd := cInts(counts), cInts(displs)
+	err := Error(C.MPI_Allgatherv(sendbuf, C.int(len(send)), C.FLOAT64, recvbuf, &cc[0], &cd[0], C.FLOAT64, cm.comm), "AllGathervF64")
+	if err != nil {
+		return err
+	}
+	return oerr
+}
+
+// AllGatherInPlaceF64 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceF64(buf []float64) error {
+	cm.countMetric("AllGatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(buf)%np != 0 {
+		return errorf("mpi.AllGatherInPlaceF64: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	n := len(buf) / np
+	recvbuf := bufPtr(buf)
+	return Error(C.MPI_Allgather(C.MPI_IN_PLACE, 0, C.FLOAT64, recvbuf, C.int(n), C.FLOAT64, cm.comm), "AllGatherInPlaceF64")
+}
+
 // ScatterF64 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
-// sendbuf is ignored on all procs except fmProc.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterF64(fmProc int, dest, orig []float64) error {
+	cm.countMetric("Scatter", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	isFrom := cm.Rank() == fmProc
+	var err error
+	if isFrom && len(orig) < cm.Size()*len(dest) {
+		err = errorf("mpi.ScatterF64: len(orig) %d < number of procs %d * len(dest) %d", len(orig), cm.Size(), len(dest))
+	}
+	if err = cm.rootCheck(fmProc, err, "ScatterF64"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
-	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+	if isFrom {
+		sendbuf = bufPtr(orig)
+	}
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.FLOAT64, recvbuf, C.int(len(dest)), C.FLOAT64, C.int(fmProc), cm.comm), "ScatterF64")
+}
+
+// ScattervF64 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervF64(fmProc int, dest, orig []float64, counts, displs []int) error {
+	cm.countMetric("Scatterv", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	rank := cm.Rank()
+	np := cm.Size()
+	isFrom := rank == fmProc
+	var err error
+	if isFrom {
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.ScattervF64: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[fmProc] > len(dest):
+			err = errorf("mpi.ScattervF64: len(dest) %d is less than counts[%d]: %d", len(dest), fmProc, counts[fmProc])
+		default:
+			err = checkCounts("ScattervF64", "orig", len(orig), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(fmProc, err, "ScattervF64"); err != nil {
+		return err
+	}
+	// a short dest on another proc cannot be reported before the collective,
+	// so that proc receives into scratch and returns the error after.
+	recv := dest
+	var derr error
+	if !isFrom && len(counts) == np && counts[rank] > len(dest) {
+		derr = errorf("mpi.ScattervF64: len(dest) %d is less than counts[%d]: %d", len(dest), rank, counts[rank])
+		recv = make([]float64, counts[rank])
+	}
+	var sendbuf unsafe.Pointer
+	recvbuf := bufPtr(recv)
+	var sc, sd *C.int
+	if isFrom {
+		sendbuf = bufPtr(orig)
+		cc, cd := cInts(counts), cInts(displs)
+		sc, sd = &cc[0], &cd[0]
+	}
+	err = Error(C.MPI_Scatterv(sendbuf, sc, sd, C.FLOAT64, recvbuf, C.int(len(recv)), C.FLOAT64, C.int(fmProc), cm.comm), "ScattervF64")
+	if err != nil {
+		return err
+	}
+	return derr
+}
+
+// AllToAllF64 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllF64(dest, orig []float64) error {
+	cm.countMetric("AllToAll", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(orig)%np != 0 || len(dest)%np != 0 {
+		return errorf("mpi.AllToAllF64: len(orig) %d and len(dest) %d must be evenly divisible by number of procs %d", len(orig), len(dest), np)
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllToAllF64: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	n := len(orig) / np
+	return Error(C.MPI_Alltoall(bufPtr(orig), C.int(n), C.FLOAT64, bufPtr(dest), C.int(n), C.FLOAT64, cm.comm), "AllToAllF64")
+}
+
+// AllToAllvF64 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvF64(dest, orig []float64, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	cm.countMetric("AllToAllv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if sendDispls == nil {
+		sendDispls, _ = Displacements(sendCounts)
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.FLOAT64, recvbuf, C.int(len(dest)), C.FLOAT64, C.int(fmProc), cm.comm), "GatherF64")
+	if recvDispls == nil {
+		recvDispls, _ = Displacements(recvCounts)
+	}
+	// the counts differ across procs, so any bad ones are reported on all procs.
+	var err error
+	if len(sendCounts) != np || len(recvCounts) != np || len(sendDispls) != np || len(recvDispls) != np {
+		err = errorf("mpi.AllToAllvF64: counts and displacements must have length equal to number of procs: %d", np)
+	} else if err = checkCounts("AllToAllvF64", "orig", len(orig), sendCounts, sendDispls); err == nil {
+		err = checkCounts("AllToAllvF64", "dest", len(dest), recvCounts, recvDispls)
+	}
+	if err = cm.allCheck(err, "AllToAllvF64"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	sc, sd := cInts(sendCounts), cInts(sendDispls)
+	rc, rd := cInts(recvCounts), cInts(recvDispls)
+	return Error(C.MPI_Alltoallv(sendbuf, &sc[0], &sd[0], C.FLOAT64, recvbuf, &rc[0], &rd[0], C.FLOAT64, cm.comm), "AllToAllvF64")
 }
 
 // SendF32 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendF32(toProc int, tag int, vals []float32) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Send", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Send(buf, C.int(len(vals)), C.FLOAT32, C.int(toProc), C.int(tag), cm.comm), "SendF32")
 }
 
-// Recv64F32 receives values from proc fmProc, using given unique tag identifier
+// RecvF32 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvF32(fmProc int, tag int, vals []float32) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Recv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.FLOAT32, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvF32")
 }
 
+// IsendF32 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendF32(toProc int, tag int, vals []float32) (*Request, error) {
+	cm.countMetric("Isend", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRequest(bufPtr(vals))
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Isend(buf, C.int(len(vals)), C.FLOAT32, C.int(toProc), C.int(tag), cm.comm, &r.req), "IsendF32")
+}
+
+// IrecvF32 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvF32(fmProc int, tag int, vals []float32) (*Request, error) {
+	cm.countMetric("Irecv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRecvRequest(bufPtr(vals), C.FLOAT32)
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Irecv(buf, C.int(len(vals)), C.FLOAT32, C.int(fmProc), C.int(tag), cm.comm, &r.req), "IrecvF32")
+}
+
 // BcastF32 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastF32(fmProc int, vals []float32) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Bcast", len(vals)*int(unsafe.Sizeof(vals[0])))
+	defer cm.enterCollective()()
+	buf := bufPtr(vals)
 	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.FLOAT32, C.int(fmProc), cm.comm), "BcastF32")
 }
 
 // ReduceF32 reduces all values across procs to toProc in orig to dest using given operation.
-// recvbuf is ignored on all procs except toProc.
+// If VerifyReduce is set, the result is checked against an AllReduce.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceF32(toProc int, op Op, dest, orig []float32) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Reduce", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := cm.checkOp(op, "ReduceF32"); err != nil {
+		return err
+	}
+	isTo := cm.Rank() == toProc
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var derr error
+	if isTo {
+		if len(dest) < len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.ReduceF32: len(dest) %d < len(orig) %d", len(dest), len(orig))
+			recvbuf = bufPtr(make([]float32, len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
 	}
-	return Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(dest)), C.FLOAT32, op.ToC(), C.int(toProc), cm.comm), "ReduceF32")
+	err := Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(orig)), C.FLOAT32, op.ToC(), C.int(toProc), cm.comm), "ReduceF32")
+	if err == nil && VerifyReduce {
+		ver := make([]float32, len(orig))
+		err = Error(C.MPI_Allreduce(sendbuf, bufPtr(ver), C.int(len(orig)), C.FLOAT32, op.ToC(), cm.comm), "ReduceF32")
+		if err == nil && isTo && derr == nil {
+			err = verifyReduce(dest, ver, "ReduceF32")
+		}
+	}
+	if derr != nil {
+		return derr
+	}
+	return err
 }
 
 // AllReduceF32 reduces all values across procs to all procs from orig into dest using given operation.
 // IMPORTANT: orig and dest must be different slices
 // To do an in-place operation, set orig to nil
 func (cm *Comm) AllReduceF32(op Op, dest, orig []float32) error {
+	cm.countMetric("AllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := cm.checkOp(op, "AllReduceF32"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
 	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+		sendbuf = bufPtr(orig)
 	} else {
 		sendbuf = C.MPI_IN_PLACE
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.FLOAT32, op.ToC(), cm.comm), "AllReduceF32")
 }
 
+// IAllReduceF32 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceF32(op Op, dest, orig []float32) (*Request, error) {
+	cm.countMetric("IAllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := cm.checkOp(op, "IAllReduceF32"); err != nil {
+		return nil, err
+	}
+	r := newRequest(bufPtr(dest))
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = bufPtr(orig)
+		if sendbuf != nil {
+			r.pin.Pin(sendbuf)
+		}
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := bufPtr(dest)
+	return r, Error(C.MPI_Iallreduce(sendbuf, recvbuf, C.int(len(dest)), C.FLOAT32, op.ToC(), cm.comm, &r.req), "IAllReduceF32")
+}
+
+// ScanF32 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanF32(op Op, dest, orig []float32) error {
+	cm.countMetric("Scan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := cm.checkOp(op, "ScanF32"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ScanF32: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	return Error(C.MPI_Scan(bufPtr(orig), bufPtr(dest), C.int(len(orig)), C.FLOAT32, op.ToC(), cm.comm), "ScanF32")
+}
+
+// ExScanF32 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanF32(op Op, dest, orig []float32) error {
+	cm.countMetric("ExScan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := cm.checkOp(op, "ExScanF32"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ExScanF32: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	recvbuf := bufPtr(dest)
+	if cm.Rank() == 0 {
+		recvbuf = bufPtr(make([]float32, len(orig)))
+	}
+	return Error(C.MPI_Exscan(bufPtr(orig), recvbuf, C.int(len(orig)), C.FLOAT32, op.ToC(), cm.comm), "ExScanF32")
+}
+
 // GatherF32 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
-// recvbuf is ignored on all procs except toProc.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherF32(toProc int, dest, orig []float32) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Gather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	var recvbuf unsafe.Pointer
+	var derr error
+	if cm.Rank() == toProc {
+		np := cm.Size()
+		if len(dest) < np*len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.GatherF32: len(dest) %d < number of procs %d * len(orig) %d", len(dest), np, len(orig))
+			recvbuf = bufPtr(make([]float32, np*len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.FLOAT32, recvbuf, C.int(len(orig)), C.FLOAT32, C.int(toProc), cm.comm), "GatherF32")
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+// GatherInPlaceF32 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceF32(toProc int, buf []float32) error {
+	cm.countMetric("GatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	isTo := cm.Rank() == toProc
+	np := cm.Size()
+	var err error
+	if isTo && len(buf)%np != 0 {
+		err = errorf("mpi.GatherInPlaceF32: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	if err = cm.rootCheck(toProc, err, "GatherInPlaceF32"); err != nil {
+		return err
+	}
+	ptr := bufPtr(buf)
+	if !isTo {
+		return Error(C.MPI_Gather(ptr, C.int(len(buf)), C.FLOAT32, nil, 0, C.FLOAT32, C.int(toProc), cm.comm), "GatherInPlaceF32")
+	}
+	n := len(buf) / np
+	return Error(C.MPI_Gather(C.MPI_IN_PLACE, 0, C.FLOAT32, ptr, C.int(n), C.FLOAT32, C.int(toProc), cm.comm), "GatherInPlaceF32")
+}
+
+// GathervF32 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervF32(toProc int, dest, orig []float32, counts, displs []int) error {
+	cm.countMetric("Gatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	isTo := cm.Rank() == toProc
+	var err error
+	if isTo {
+		np := cm.Size()
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.GathervF32: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[toProc] != len(orig):
+			err = errorf("mpi.GathervF32: counts[%d] %d is not equal to len(orig): %d", toProc, counts[toProc], len(orig))
+		default:
+			err = checkCounts("GathervF32", "dest", len(dest), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(toProc, err, "GathervF32"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var rc, rd *C.int
+	if isTo {
+		recvbuf = bufPtr(dest)
+		cc, cd := cInts(counts), cInts(displs)
+		rc, rd = &cc[0], &cd[0]
 	}
-	return Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.FLOAT32, recvbuf, C.int(len(orig)), C.FLOAT32, C.int(toProc), cm.comm), "GatherF32")
+	return Error(C.MPI_Gatherv(sendbuf, C.int(len(orig)), C.FLOAT32, recvbuf, rc, rd, C.FLOAT32, C.int(toProc), cm.comm), "GathervF32")
 }
 
 // AllGatherF32 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) AllGatherF32(dest, orig []float32) error {
-	sendbuf := unsafe.Pointer(&orig[0])
-	recvbuf := unsafe.Pointer(&dest[0])
+	cm.countMetric("AllGather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.FLOAT32, recvbuf, C.int(len(orig)), C.FLOAT32, cm.comm), "GatherF32")
 }
 
+// AllGathervF32 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervF32(dest, orig []float32, counts, displs []int) error {
+	cm.countMetric("AllGatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if displs == nil {
+		displs, _ = Displacements(counts)
+	}
+	// counts and displs are the same on all procs, so all procs return together.
+	if len(counts) != np || len(displs) != np {
+		return errorf("mpi.AllGathervF32: counts and displacements must have length equal to number of procs: %d", np)
+	}
+	if err := checkCounts("AllGathervF32", "dest", len(dest), counts, displs); err != nil {
+		return err
+	}
+	// but len(orig) is only known locally, so a mismatch is sent from
+	// scratch, to keep the other procs from blocking, and returned after.
+	rank := cm.Rank()
+	send := orig
+	var oerr error
+	if counts[rank] != len(orig) {
+		oerr = errorf("mpi.AllGathervF32: counts[%d] %d is not equal to len(orig): %d", rank, counts[rank], len(orig))
+		send = make([]float32, counts[rank])
+		copy(send, orig)
+	}
+	sendbuf := bufPtr(send)
+	recvbuf := bufPtr(dest)
+	cc, cd := cInts(counts), cInts(displs)
+	err := Error(C.MPI_Allgatherv(sendbuf, C.int(len(send)), C.FLOAT32, recvbuf, &cc[0], &cd[0], C.FLOAT32, cm.comm), "AllGathervF32")
+	if err != nil {
+		return err
+	}
+	return oerr
+}
+
+// AllGatherInPlaceF32 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceF32(buf []float32) error {
+	cm.countMetric("AllGatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(buf)%np != 0 {
+		return errorf("mpi.AllGatherInPlaceF32: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	n := len(buf) / np
+	recvbuf := bufPtr(buf)
+	return Error(C.MPI_Allgather(C.MPI_IN_PLACE, 0, C.FLOAT32, recvbuf, C.int(n), C.FLOAT32, cm.comm), "AllGatherInPlaceF32")
+}
+
 // ScatterF32 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
-// sendbuf is ignored on all procs except fmProc.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterF32(fmProc int, dest, orig []float32) error {
+	cm.countMetric("Scatter", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	isFrom := cm.Rank() == fmProc
+	var err error
+	if isFrom && len(orig) < cm.Size()*len(dest) {
+		err = errorf("mpi.ScatterF32: len(orig) %d < number of procs %d * len(dest) %d", len(orig), cm.Size(), len(dest))
+	}
+	if err = cm.rootCheck(fmProc, err, "ScatterF32"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
-	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+	if isFrom {
+		sendbuf = bufPtr(orig)
+	}
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.FLOAT32, recvbuf, C.int(len(dest)), C.FLOAT32, C.int(fmProc), cm.comm), "ScatterF32")
+}
+
+// ScattervF32 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervF32(fmProc int, dest, orig []float32, counts, displs []int) error {
+	cm.countMetric("Scatterv", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	rank := cm.Rank()
+	np := cm.Size()
+	isFrom := rank == fmProc
+	var err error
+	if isFrom {
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.ScattervF32: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[fmProc] > len(dest):
+			err = errorf("mpi.ScattervF32: len(dest) %d is less than counts[%d]: %d", len(dest), fmProc, counts[fmProc])
+		default:
+			err = checkCounts("ScattervF32", "orig", len(orig), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(fmProc, err, "ScattervF32"); err != nil {
+		return err
+	}
+	// a short dest on another proc cannot be reported before the collective,
+	// so that proc receives into scratch and returns the error after.
+	recv := dest
+	var derr error
+	if !isFrom && len(counts) == np && counts[rank] > len(dest) {
+		derr = errorf("mpi.ScattervF32: len(dest) %d is less than counts[%d]: %d", len(dest), rank, counts[rank])
+		recv = make([]float32, counts[rank])
+	}
+	var sendbuf unsafe.Pointer
+	recvbuf := bufPtr(recv)
+	var sc, sd *C.int
+	if isFrom {
+		sendbuf = bufPtr(orig)
+		cc, cd := cInts(counts), cInts(displs)
+		sc, sd = &cc[0], &cd[0]
+	}
+	err = Error(C.MPI_Scatterv(sendbuf, sc, sd, C.FLOAT32, recvbuf, C.int(len(recv)), C.FLOAT32, C.int(fmProc), cm.comm), "ScattervF32")
+	if err != nil {
+		return err
+	}
+	return derr
+}
+
+// AllToAllF32 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllF32(dest, orig []float32) error {
+	cm.countMetric("AllToAll", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(orig)%np != 0 || len(dest)%np != 0 {
+		return errorf("mpi.AllToAllF32: len(orig) %d and len(dest) %d must be evenly divisible by number of procs %d", len(orig), len(dest), np)
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllToAllF32: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	n := len(orig) / np
+	return Error(C.MPI_Alltoall(bufPtr(orig), C.int(n), C.FLOAT32, bufPtr(dest), C.int(n), C.FLOAT32, cm.comm), "AllToAllF32")
+}
+
+// AllToAllvF32 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvF32(dest, orig []float32, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	cm.countMetric("AllToAllv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if sendDispls == nil {
+		sendDispls, _ = Displacements(sendCounts)
+	}
+	if recvDispls == nil {
+		recvDispls, _ = Displacements(recvCounts)
+	}
+	// the counts differ across procs, so any bad ones are reported on all procs.
+	var err error
+	if len(sendCounts) != np || len(recvCounts) != np || len(sendDispls) != np || len(recvDispls) != np {
+		err = errorf("mpi.AllToAllvF32: counts and displacements must have length equal to number of procs: %d", np)
+	} else if err = checkCounts("AllToAllvF32", "orig", len(orig), sendCounts, sendDispls); err == nil {
+		err = checkCounts("AllToAllvF32", "dest", len(dest), recvCounts, recvDispls)
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.FLOAT32, recvbuf, C.int(len(dest)), C.FLOAT32, C.int(fmProc), cm.comm), "GatherF32")
+	if err = cm.allCheck(err, "AllToAllvF32"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	sc, sd := cInts(sendCounts), cInts(sendDispls)
+	rc, rd := cInts(recvCounts), cInts(recvDispls)
+	return Error(C.MPI_Alltoallv(sendbuf, &sc[0], &sd[0], C.FLOAT32, recvbuf, &rc[0], &rd[0], C.FLOAT32, cm.comm), "AllToAllvF32")
 }
 
 // SendInt sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendInt(toProc int, tag int, vals []int) error {
-	buf := unsafe.Pointer(&vals[0])
-	return Error(C.MPI_Send(buf, C.int(len(vals)), C.INT64, C.int(toProc), C.int(tag), cm.comm), "SendInt")
+	cm.countMetric("Send", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
+	return Error(C.MPI_Send(buf, C.int(len(vals)), C.GOINT, C.int(toProc), C.int(tag), cm.comm), "SendInt")
 }
 
-// Recv64Int receives values from proc fmProc, using given unique tag identifier
+// RecvInt receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvInt(fmProc int, tag int, vals []int) error {
-	buf := unsafe.Pointer(&vals[0])
-	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.INT64, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvInt")
+	cm.countMetric("Recv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
+	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.GOINT, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvInt")
+}
+
+// IsendInt starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendInt(toProc int, tag int, vals []int) (*Request, error) {
+	cm.countMetric("Isend", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRequest(bufPtr(vals))
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Isend(buf, C.int(len(vals)), C.GOINT, C.int(toProc), C.int(tag), cm.comm, &r.req), "IsendInt")
+}
+
+// IrecvInt starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvInt(fmProc int, tag int, vals []int) (*Request, error) {
+	cm.countMetric("Irecv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRecvRequest(bufPtr(vals), C.GOINT)
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Irecv(buf, C.int(len(vals)), C.GOINT, C.int(fmProc), C.int(tag), cm.comm, &r.req), "IrecvInt")
 }
 
 // BcastInt broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastInt(fmProc int, vals []int) error {
-	buf := unsafe.Pointer(&vals[0])
-	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.INT64, C.int(fmProc), cm.comm), "BcastInt")
+	cm.countMetric("Bcast", len(vals)*int(unsafe.Sizeof(vals[0])))
+	defer cm.enterCollective()()
+	buf := bufPtr(vals)
+	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.GOINT, C.int(fmProc), cm.comm), "BcastInt")
 }
 
 // ReduceInt reduces all values across procs to toProc in orig to dest using given operation.
-// recvbuf is ignored on all procs except toProc.
+// If VerifyReduce is set, the result is checked against an AllReduce.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceInt(toProc int, op Op, dest, orig []int) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Reduce", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ReduceInt"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ReduceInt"); err != nil {
+		return err
+	}
+	isTo := cm.Rank() == toProc
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var derr error
+	if isTo {
+		if len(dest) < len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.ReduceInt: len(dest) %d < len(orig) %d", len(dest), len(orig))
+			recvbuf = bufPtr(make([]int, len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(orig)), C.GOINT, op.ToC(), C.int(toProc), cm.comm), "ReduceInt")
+	if err == nil && VerifyReduce {
+		ver := make([]int, len(orig))
+		err = Error(C.MPI_Allreduce(sendbuf, bufPtr(ver), C.int(len(orig)), C.GOINT, op.ToC(), cm.comm), "ReduceInt")
+		if err == nil && isTo && derr == nil {
+			err = verifyReduce(dest, ver, "ReduceInt")
+		}
 	}
-	return Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(dest)), C.INT64, op.ToC(), C.int(toProc), cm.comm), "ReduceInt")
+	if derr != nil {
+		return derr
+	}
+	return err
 }
 
 // AllReduceInt reduces all values across procs to all procs from orig into dest using given operation.
 // IMPORTANT: orig and dest must be different slices
 // To do an in-place operation, set orig to nil
 func (cm *Comm) AllReduceInt(op Op, dest, orig []int) error {
+	cm.countMetric("AllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceInt"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceInt"); err != nil {
+		return err
+	}
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = bufPtr(orig)
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.GOINT, op.ToC(), cm.comm), "AllReduceInt")
+}
+
+// IAllReduceInt starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceInt(op Op, dest, orig []int) (*Request, error) {
+	cm.countMetric("IAllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "IAllReduceInt"); err != nil {
+		return nil, err
+	}
+	if err := cm.checkOp(op, "IAllReduceInt"); err != nil {
+		return nil, err
+	}
+	r := newRequest(bufPtr(dest))
 	var sendbuf unsafe.Pointer
 	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+		sendbuf = bufPtr(orig)
+		if sendbuf != nil {
+			r.pin.Pin(sendbuf)
+		}
 	} else {
 		sendbuf = C.MPI_IN_PLACE
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.INT64, op.ToC(), cm.comm), "AllReduceInt")
+	recvbuf := bufPtr(dest)
+	return r, Error(C.MPI_Iallreduce(sendbuf, recvbuf, C.int(len(dest)), C.GOINT, op.ToC(), cm.comm, &r.req), "IAllReduceInt")
+}
+
+// ScanInt computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanInt(op Op, dest, orig []int) error {
+	cm.countMetric("Scan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ScanInt"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ScanInt"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ScanInt: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	return Error(C.MPI_Scan(bufPtr(orig), bufPtr(dest), C.int(len(orig)), C.GOINT, op.ToC(), cm.comm), "ScanInt")
+}
+
+// ExScanInt computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanInt(op Op, dest, orig []int) error {
+	cm.countMetric("ExScan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ExScanInt"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ExScanInt"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ExScanInt: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	recvbuf := bufPtr(dest)
+	if cm.Rank() == 0 {
+		recvbuf = bufPtr(make([]int, len(orig)))
+	}
+	return Error(C.MPI_Exscan(bufPtr(orig), recvbuf, C.int(len(orig)), C.GOINT, op.ToC(), cm.comm), "ExScanInt")
 }
 
 // GatherInt gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
-// recvbuf is ignored on all procs except toProc.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherInt(toProc int, dest, orig []int) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Gather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	var recvbuf unsafe.Pointer
+	var derr error
+	if cm.Rank() == toProc {
+		np := cm.Size()
+		if len(dest) < np*len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.GatherInt: len(dest) %d < number of procs %d * len(orig) %d", len(dest), np, len(orig))
+			recvbuf = bufPtr(make([]int, np*len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.GOINT, recvbuf, C.int(len(orig)), C.GOINT, C.int(toProc), cm.comm), "GatherInt")
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+// GatherInPlaceInt gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceInt(toProc int, buf []int) error {
+	cm.countMetric("GatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	isTo := cm.Rank() == toProc
+	np := cm.Size()
+	var err error
+	if isTo && len(buf)%np != 0 {
+		err = errorf("mpi.GatherInPlaceInt: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	if err = cm.rootCheck(toProc, err, "GatherInPlaceInt"); err != nil {
+		return err
+	}
+	ptr := bufPtr(buf)
+	if !isTo {
+		return Error(C.MPI_Gather(ptr, C.int(len(buf)), C.GOINT, nil, 0, C.GOINT, C.int(toProc), cm.comm), "GatherInPlaceInt")
+	}
+	n := len(buf) / np
+	return Error(C.MPI_Gather(C.MPI_IN_PLACE, 0, C.GOINT, ptr, C.int(n), C.GOINT, C.int(toProc), cm.comm), "GatherInPlaceInt")
+}
+
+// GathervInt gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervInt(toProc int, dest, orig []int, counts, displs []int) error {
+	cm.countMetric("Gatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	isTo := cm.Rank() == toProc
+	var err error
+	if isTo {
+		np := cm.Size()
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.GathervInt: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[toProc] != len(orig):
+			err = errorf("mpi.GathervInt: counts[%d] %d is not equal to len(orig): %d", toProc, counts[toProc], len(orig))
+		default:
+			err = checkCounts("GathervInt", "dest", len(dest), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(toProc, err, "GathervInt"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var rc, rd *C.int
+	if isTo {
+		recvbuf = bufPtr(dest)
+		cc, cd := cInts(counts), cInts(displs)
+		rc, rd = &cc[0], &cd[0]
 	}
-	return Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.INT64, recvbuf, C.int(len(orig)), C.INT64, C.int(toProc), cm.comm), "GatherInt")
+	return Error(C.MPI_Gatherv(sendbuf, C.int(len(orig)), C.GOINT, recvbuf, rc, rd, C.GOINT, C.int(toProc), cm.comm), "GathervInt")
 }
 
 // AllGatherInt gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) AllGatherInt(dest, orig []int) error {
-	sendbuf := unsafe.Pointer(&orig[0])
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.INT64, recvbuf, C.int(len(orig)), C.INT64, cm.comm), "GatherInt")
+	cm.countMetric("AllGather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.GOINT, recvbuf, C.int(len(orig)), C.GOINT, cm.comm), "GatherInt")
+}
+
+// AllGathervInt gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervInt(dest, orig []int, counts, displs []int) error {
+	cm.countMetric("AllGatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if displs == nil {
+		displs, _ = Displacements(counts)
+	}
+	// counts and displs are the same on all procs, so all procs return together.
+	if len(counts) != np || len(displs) != np {
+		return errorf("mpi.AllGathervInt: counts and displacements must have length equal to number of procs: %d", np)
+	}
+	if err := checkCounts("AllGathervInt", "dest", len(dest), counts, displs); err != nil {
+		return err
+	}
+	// but len(orig) is only known locally, so a mismatch is sent from
+	// scratch, to keep the other procs from blocking, and returned after.
+	rank := cm.Rank()
+	send := orig
+	var oerr error
+	if counts[rank] != len(orig) {
+		oerr = errorf("mpi.AllGathervInt: counts[%d] %d is not equal to len(orig): %d", rank, counts[rank], len(orig))
+		send = make([]int, counts[rank])
+		copy(send, orig)
+	}
+	sendbuf := bufPtr(send)
+	recvbuf := bufPtr(dest)
+	cc, cd := cInts(counts), cInts(displs)
+	err := Error(C.MPI_Allgatherv(sendbuf, C.int(len(send)), C.GOINT, recvbuf, &cc[0], &cd[0], C.GOINT, cm.comm), "AllGathervInt")
+	if err != nil {
+		return err
+	}
+	return oerr
+}
+
+// AllGatherInPlaceInt gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceInt(buf []int) error {
+	cm.countMetric("AllGatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(buf)%np != 0 {
+		return errorf("mpi.AllGatherInPlaceInt: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	n := len(buf) / np
+	recvbuf := bufPtr(buf)
+	return Error(C.MPI_Allgather(C.MPI_IN_PLACE, 0, C.GOINT, recvbuf, C.int(n), C.GOINT, cm.comm), "AllGatherInPlaceInt")
 }
 
 // ScatterInt scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
-// sendbuf is ignored on all procs except fmProc.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterInt(fmProc int, dest, orig []int) error {
+	cm.countMetric("Scatter", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	isFrom := cm.Rank() == fmProc
+	var err error
+	if isFrom && len(orig) < cm.Size()*len(dest) {
+		err = errorf("mpi.ScatterInt: len(orig) %d < number of procs %d * len(dest) %d", len(orig), cm.Size(), len(dest))
+	}
+	if err = cm.rootCheck(fmProc, err, "ScatterInt"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
-	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+	if isFrom {
+		sendbuf = bufPtr(orig)
+	}
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.GOINT, recvbuf, C.int(len(dest)), C.GOINT, C.int(fmProc), cm.comm), "ScatterInt")
+}
+
+// ScattervInt scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervInt(fmProc int, dest, orig []int, counts, displs []int) error {
+	cm.countMetric("Scatterv", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	rank := cm.Rank()
+	np := cm.Size()
+	isFrom := rank == fmProc
+	var err error
+	if isFrom {
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.ScattervInt: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[fmProc] > len(dest):
+			err = errorf("mpi.ScattervInt: len(dest) %d is less than counts[%d]: %d", len(dest), fmProc, counts[fmProc])
+		default:
+			err = checkCounts("ScattervInt", "orig", len(orig), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(fmProc, err, "ScattervInt"); err != nil {
+		return err
+	}
+	// a short dest on another proc cannot be reported before the collective,
+	// so that proc receives into scratch and returns the error after.
+	recv := dest
+	var derr error
+	if !isFrom && len(counts) == np && counts[rank] > len(dest) {
+		derr = errorf("mpi.ScattervInt: len(dest) %d is less than counts[%d]: %d", len(dest), rank, counts[rank])
+		recv = make([]int, counts[rank])
+	}
+	var sendbuf unsafe.Pointer
+	recvbuf := bufPtr(recv)
+	var sc, sd *C.int
+	if isFrom {
+		sendbuf = bufPtr(orig)
+		cc, cd := cInts(counts), cInts(displs)
+		sc, sd = &cc[0], &cd[0]
+	}
+	err = Error(C.MPI_Scatterv(sendbuf, sc, sd, C.GOINT, recvbuf, C.int(len(recv)), C.GOINT, C.int(fmProc), cm.comm), "ScattervInt")
+	if err != nil {
+		return err
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.INT64, recvbuf, C.int(len(dest)), C.INT64, C.int(fmProc), cm.comm), "GatherInt")
+	return derr
+}
+
+// AllToAllInt sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllInt(dest, orig []int) error {
+	cm.countMetric("AllToAll", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(orig)%np != 0 || len(dest)%np != 0 {
+		return errorf("mpi.AllToAllInt: len(orig) %d and len(dest) %d must be evenly divisible by number of procs %d", len(orig), len(dest), np)
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllToAllInt: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	n := len(orig) / np
+	return Error(C.MPI_Alltoall(bufPtr(orig), C.int(n), C.GOINT, bufPtr(dest), C.int(n), C.GOINT, cm.comm), "AllToAllInt")
+}
+
+// AllToAllvInt sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvInt(dest, orig []int, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	cm.countMetric("AllToAllv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if sendDispls == nil {
+		sendDispls, _ = Displacements(sendCounts)
+	}
+	if recvDispls == nil {
+		recvDispls, _ = Displacements(recvCounts)
+	}
+	// the counts differ across procs, so any bad ones are reported on all procs.
+	var err error
+	if len(sendCounts) != np || len(recvCounts) != np || len(sendDispls) != np || len(recvDispls) != np {
+		err = errorf("mpi.AllToAllvInt: counts and displacements must have length equal to number of procs: %d", np)
+	} else if err = checkCounts("AllToAllvInt", "orig", len(orig), sendCounts, sendDispls); err == nil {
+		err = checkCounts("AllToAllvInt", "dest", len(dest), recvCounts, recvDispls)
+	}
+	if err = cm.allCheck(err, "AllToAllvInt"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	sc, sd := cInts(sendCounts), cInts(sendDispls)
+	rc, rd := cInts(recvCounts), cInts(recvDispls)
+	return Error(C.MPI_Alltoallv(sendbuf, &sc[0], &sd[0], C.GOINT, recvbuf, &rc[0], &rd[0], C.GOINT, cm.comm), "AllToAllvInt")
 }
 
 // SendI64 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendI64(toProc int, tag int, vals []int64) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Send", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Send(buf, C.int(len(vals)), C.INT64, C.int(toProc), C.int(tag), cm.comm), "SendI64")
 }
 
-// Recv64I64 receives values from proc fmProc, using given unique tag identifier
+// RecvI64 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvI64(fmProc int, tag int, vals []int64) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Recv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.INT64, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvI64")
 }
 
+// IsendI64 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendI64(toProc int, tag int, vals []int64) (*Request, error) {
+	cm.countMetric("Isend", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRequest(bufPtr(vals))
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Isend(buf, C.int(len(vals)), C.INT64, C.int(toProc), C.int(tag), cm.comm, &r.req), "IsendI64")
+}
+
+// IrecvI64 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvI64(fmProc int, tag int, vals []int64) (*Request, error) {
+	cm.countMetric("Irecv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRecvRequest(bufPtr(vals), C.INT64)
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Irecv(buf, C.int(len(vals)), C.INT64, C.int(fmProc), C.int(tag), cm.comm, &r.req), "IrecvI64")
+}
+
 // BcastI64 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastI64(fmProc int, vals []int64) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Bcast", len(vals)*int(unsafe.Sizeof(vals[0])))
+	defer cm.enterCollective()()
+	buf := bufPtr(vals)
 	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.INT64, C.int(fmProc), cm.comm), "BcastI64")
 }
 
 // ReduceI64 reduces all values across procs to toProc in orig to dest using given operation.
-// recvbuf is ignored on all procs except toProc.
+// If VerifyReduce is set, the result is checked against an AllReduce.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceI64(toProc int, op Op, dest, orig []int64) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Reduce", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ReduceI64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ReduceI64"); err != nil {
+		return err
+	}
+	isTo := cm.Rank() == toProc
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var derr error
+	if isTo {
+		if len(dest) < len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.ReduceI64: len(dest) %d < len(orig) %d", len(dest), len(orig))
+			recvbuf = bufPtr(make([]int64, len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(orig)), C.INT64, op.ToC(), C.int(toProc), cm.comm), "ReduceI64")
+	if err == nil && VerifyReduce {
+		ver := make([]int64, len(orig))
+		err = Error(C.MPI_Allreduce(sendbuf, bufPtr(ver), C.int(len(orig)), C.INT64, op.ToC(), cm.comm), "ReduceI64")
+		if err == nil && isTo && derr == nil {
+			err = verifyReduce(dest, ver, "ReduceI64")
+		}
+	}
+	if derr != nil {
+		return derr
 	}
-	return Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(dest)), C.INT64, op.ToC(), C.int(toProc), cm.comm), "ReduceI64")
+	return err
 }
 
 // AllReduceI64 reduces all values across procs to all procs from orig into dest using given operation.
 // IMPORTANT: orig and dest must be different slices
 // To do an in-place operation, set orig to nil
 func (cm *Comm) AllReduceI64(op Op, dest, orig []int64) error {
+	cm.countMetric("AllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceI64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceI64"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
 	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+		sendbuf = bufPtr(orig)
 	} else {
 		sendbuf = C.MPI_IN_PLACE
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.INT64, op.ToC(), cm.comm), "AllReduceI64")
 }
 
+// IAllReduceI64 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceI64(op Op, dest, orig []int64) (*Request, error) {
+	cm.countMetric("IAllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "IAllReduceI64"); err != nil {
+		return nil, err
+	}
+	if err := cm.checkOp(op, "IAllReduceI64"); err != nil {
+		return nil, err
+	}
+	r := newRequest(bufPtr(dest))
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = bufPtr(orig)
+		if sendbuf != nil {
+			r.pin.Pin(sendbuf)
+		}
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := bufPtr(dest)
+	return r, Error(C.MPI_Iallreduce(sendbuf, recvbuf, C.int(len(dest)), C.INT64, op.ToC(), cm.comm, &r.req), "IAllReduceI64")
+}
+
+// ScanI64 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanI64(op Op, dest, orig []int64) error {
+	cm.countMetric("Scan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ScanI64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ScanI64"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ScanI64: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	return Error(C.MPI_Scan(bufPtr(orig), bufPtr(dest), C.int(len(orig)), C.INT64, op.ToC(), cm.comm), "ScanI64")
+}
+
+// ExScanI64 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanI64(op Op, dest, orig []int64) error {
+	cm.countMetric("ExScan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ExScanI64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ExScanI64"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ExScanI64: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	recvbuf := bufPtr(dest)
+	if cm.Rank() == 0 {
+		recvbuf = bufPtr(make([]int64, len(orig)))
+	}
+	return Error(C.MPI_Exscan(bufPtr(orig), recvbuf, C.int(len(orig)), C.INT64, op.ToC(), cm.comm), "ExScanI64")
+}
+
 // GatherI64 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
-// recvbuf is ignored on all procs except toProc.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherI64(toProc int, dest, orig []int64) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Gather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	var recvbuf unsafe.Pointer
+	var derr error
+	if cm.Rank() == toProc {
+		np := cm.Size()
+		if len(dest) < np*len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.GatherI64: len(dest) %d < number of procs %d * len(orig) %d", len(dest), np, len(orig))
+			recvbuf = bufPtr(make([]int64, np*len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.INT64, recvbuf, C.int(len(orig)), C.INT64, C.int(toProc), cm.comm), "GatherI64")
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+// GatherInPlaceI64 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceI64(toProc int, buf []int64) error {
+	cm.countMetric("GatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	isTo := cm.Rank() == toProc
+	np := cm.Size()
+	var err error
+	if isTo && len(buf)%np != 0 {
+		err = errorf("mpi.GatherInPlaceI64: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	if err = cm.rootCheck(toProc, err, "GatherInPlaceI64"); err != nil {
+		return err
+	}
+	ptr := bufPtr(buf)
+	if !isTo {
+		return Error(C.MPI_Gather(ptr, C.int(len(buf)), C.INT64, nil, 0, C.INT64, C.int(toProc), cm.comm), "GatherInPlaceI64")
+	}
+	n := len(buf) / np
+	return Error(C.MPI_Gather(C.MPI_IN_PLACE, 0, C.INT64, ptr, C.int(n), C.INT64, C.int(toProc), cm.comm), "GatherInPlaceI64")
+}
+
+// GathervI64 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervI64(toProc int, dest, orig []int64, counts, displs []int) error {
+	cm.countMetric("Gatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	isTo := cm.Rank() == toProc
+	var err error
+	if isTo {
+		np := cm.Size()
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.GathervI64: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[toProc] != len(orig):
+			err = errorf("mpi.GathervI64: counts[%d] %d is not equal to len(orig): %d", toProc, counts[toProc], len(orig))
+		default:
+			err = checkCounts("GathervI64", "dest", len(dest), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(toProc, err, "GathervI64"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var rc, rd *C.int
+	if isTo {
+		recvbuf = bufPtr(dest)
+		cc, cd := cInts(counts), cInts(displs)
+		rc, rd = &cc[0], &cd[0]
 	}
-	return Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.INT64, recvbuf, C.int(len(orig)), C.INT64, C.int(toProc), cm.comm), "GatherI64")
+	return Error(C.MPI_Gatherv(sendbuf, C.int(len(orig)), C.INT64, recvbuf, rc, rd, C.INT64, C.int(toProc), cm.comm), "GathervI64")
 }
 
 // AllGatherI64 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) AllGatherI64(dest, orig []int64) error {
-	sendbuf := unsafe.Pointer(&orig[0])
-	recvbuf := unsafe.Pointer(&dest[0])
+	cm.countMetric("AllGather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.INT64, recvbuf, C.int(len(orig)), C.INT64, cm.comm), "GatherI64")
 }
 
+// AllGathervI64 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervI64(dest, orig []int64, counts, displs []int) error {
+	cm.countMetric("AllGatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if displs == nil {
+		displs, _ = Displacements(counts)
+	}
+	// counts and displs are the same on all procs, so all procs return together.
+	if len(counts) != np || len(displs) != np {
+		return errorf("mpi.AllGathervI64: counts and displacements must have length equal to number of procs: %d", np)
+	}
+	if err := checkCounts("AllGathervI64", "dest", len(dest), counts, displs); err != nil {
+		return err
+	}
+	// but len(orig) is only known locally, so a mismatch is sent from
+	// scratch, to keep the other procs from blocking, and returned after.
+	rank := cm.Rank()
+	send := orig
+	var oerr error
+	if counts[rank] != len(orig) {
+		oerr = errorf("mpi.AllGathervI64: counts[%d] %d is not equal to len(orig): %d", rank, counts[rank], len(orig))
+		send = make([]int64, counts[rank])
+		copy(send, orig)
+	}
+	sendbuf := bufPtr(send)
+	recvbuf := bufPtr(dest)
+	cc, cd := cInts(counts), cInts(displs)
+	err := Error(C.MPI_Allgatherv(sendbuf, C.int(len(send)), C.INT64, recvbuf, &cc[0], &cd[0], C.INT64, cm.comm), "AllGathervI64")
+	if err != nil {
+		return err
+	}
+	return oerr
+}
+
+// AllGatherInPlaceI64 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceI64(buf []int64) error {
+	cm.countMetric("AllGatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(buf)%np != 0 {
+		return errorf("mpi.AllGatherInPlaceI64: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	n := len(buf) / np
+	recvbuf := bufPtr(buf)
+	return Error(C.MPI_Allgather(C.MPI_IN_PLACE, 0, C.INT64, recvbuf, C.int(n), C.INT64, cm.comm), "AllGatherInPlaceI64")
+}
+
 // ScatterI64 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
-// sendbuf is ignored on all procs except fmProc.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterI64(fmProc int, dest, orig []int64) error {
+	cm.countMetric("Scatter", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	isFrom := cm.Rank() == fmProc
+	var err error
+	if isFrom && len(orig) < cm.Size()*len(dest) {
+		err = errorf("mpi.ScatterI64: len(orig) %d < number of procs %d * len(dest) %d", len(orig), cm.Size(), len(dest))
+	}
+	if err = cm.rootCheck(fmProc, err, "ScatterI64"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
-	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+	if isFrom {
+		sendbuf = bufPtr(orig)
+	}
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.INT64, recvbuf, C.int(len(dest)), C.INT64, C.int(fmProc), cm.comm), "ScatterI64")
+}
+
+// ScattervI64 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervI64(fmProc int, dest, orig []int64, counts, displs []int) error {
+	cm.countMetric("Scatterv", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	rank := cm.Rank()
+	np := cm.Size()
+	isFrom := rank == fmProc
+	var err error
+	if isFrom {
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.ScattervI64: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[fmProc] > len(dest):
+			err = errorf("mpi.ScattervI64: len(dest) %d is less than counts[%d]: %d", len(dest), fmProc, counts[fmProc])
+		default:
+			err = checkCounts("ScattervI64", "orig", len(orig), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(fmProc, err, "ScattervI64"); err != nil {
+		return err
+	}
+	// a short dest on another proc cannot be reported before the collective,
+	// so that proc receives into scratch and returns the error after.
+	recv := dest
+	var derr error
+	if !isFrom && len(counts) == np && counts[rank] > len(dest) {
+		derr = errorf("mpi.ScattervI64: len(dest) %d is less than counts[%d]: %d", len(dest), rank, counts[rank])
+		recv = make([]int64, counts[rank])
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.INT64, recvbuf, C.int(len(dest)), C.INT64, C.int(fmProc), cm.comm), "GatherI64")
+	var sendbuf unsafe.Pointer
+	recvbuf := bufPtr(recv)
+	var sc, sd *C.int
+	if isFrom {
+		sendbuf = bufPtr(orig)
+		cc, cd := cInts(counts), cInts(displs)
+		sc, sd = &cc[0], &cd[0]
+	}
+	err = Error(C.MPI_Scatterv(sendbuf, sc, sd, C.INT64, recvbuf, C.int(len(recv)), C.INT64, C.int(fmProc), cm.comm), "ScattervI64")
+	if err != nil {
+		return err
+	}
+	return derr
+}
+
+// AllToAllI64 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllI64(dest, orig []int64) error {
+	cm.countMetric("AllToAll", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(orig)%np != 0 || len(dest)%np != 0 {
+		return errorf("mpi.AllToAllI64: len(orig) %d and len(dest) %d must be evenly divisible by number of procs %d", len(orig), len(dest), np)
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllToAllI64: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	n := len(orig) / np
+	return Error(C.MPI_Alltoall(bufPtr(orig), C.int(n), C.INT64, bufPtr(dest), C.int(n), C.INT64, cm.comm), "AllToAllI64")
+}
+
+// AllToAllvI64 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvI64(dest, orig []int64, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	cm.countMetric("AllToAllv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if sendDispls == nil {
+		sendDispls, _ = Displacements(sendCounts)
+	}
+	if recvDispls == nil {
+		recvDispls, _ = Displacements(recvCounts)
+	}
+	// the counts differ across procs, so any bad ones are reported on all procs.
+	var err error
+	if len(sendCounts) != np || len(recvCounts) != np || len(sendDispls) != np || len(recvDispls) != np {
+		err = errorf("mpi.AllToAllvI64: counts and displacements must have length equal to number of procs: %d", np)
+	} else if err = checkCounts("AllToAllvI64", "orig", len(orig), sendCounts, sendDispls); err == nil {
+		err = checkCounts("AllToAllvI64", "dest", len(dest), recvCounts, recvDispls)
+	}
+	if err = cm.allCheck(err, "AllToAllvI64"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	sc, sd := cInts(sendCounts), cInts(sendDispls)
+	rc, rd := cInts(recvCounts), cInts(recvDispls)
+	return Error(C.MPI_Alltoallv(sendbuf, &sc[0], &sd[0], C.INT64, recvbuf, &rc[0], &rd[0], C.INT64, cm.comm), "AllToAllvI64")
 }
 
 // SendU64 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendU64(toProc int, tag int, vals []uint64) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Send", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Send(buf, C.int(len(vals)), C.UINT64, C.int(toProc), C.int(tag), cm.comm), "SendU64")
 }
 
-// Recv64U64 receives values from proc fmProc, using given unique tag identifier
+// RecvU64 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvU64(fmProc int, tag int, vals []uint64) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Recv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.UINT64, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvU64")
 }
 
+// IsendU64 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendU64(toProc int, tag int, vals []uint64) (*Request, error) {
+	cm.countMetric("Isend", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRequest(bufPtr(vals))
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Isend(buf, C.int(len(vals)), C.UINT64, C.int(toProc), C.int(tag), cm.comm, &r.req), "IsendU64")
+}
+
+// IrecvU64 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvU64(fmProc int, tag int, vals []uint64) (*Request, error) {
+	cm.countMetric("Irecv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRecvRequest(bufPtr(vals), C.UINT64)
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Irecv(buf, C.int(len(vals)), C.UINT64, C.int(fmProc), C.int(tag), cm.comm, &r.req), "IrecvU64")
+}
+
 // BcastU64 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastU64(fmProc int, vals []uint64) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Bcast", len(vals)*int(unsafe.Sizeof(vals[0])))
+	defer cm.enterCollective()()
+	buf := bufPtr(vals)
 	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.UINT64, C.int(fmProc), cm.comm), "BcastU64")
 }
 
 // ReduceU64 reduces all values across procs to toProc in orig to dest using given operation.
-// recvbuf is ignored on all procs except toProc.
+// If VerifyReduce is set, the result is checked against an AllReduce.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceU64(toProc int, op Op, dest, orig []uint64) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Reduce", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ReduceU64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ReduceU64"); err != nil {
+		return err
+	}
+	isTo := cm.Rank() == toProc
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var derr error
+	if isTo {
+		if len(dest) < len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.ReduceU64: len(dest) %d < len(orig) %d", len(dest), len(orig))
+			recvbuf = bufPtr(make([]uint64, len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(orig)), C.UINT64, op.ToC(), C.int(toProc), cm.comm), "ReduceU64")
+	if err == nil && VerifyReduce {
+		ver := make([]uint64, len(orig))
+		err = Error(C.MPI_Allreduce(sendbuf, bufPtr(ver), C.int(len(orig)), C.UINT64, op.ToC(), cm.comm), "ReduceU64")
+		if err == nil && isTo && derr == nil {
+			err = verifyReduce(dest, ver, "ReduceU64")
+		}
 	}
-	return Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(dest)), C.UINT64, op.ToC(), C.int(toProc), cm.comm), "ReduceU64")
+	if derr != nil {
+		return derr
+	}
+	return err
 }
 
 // AllReduceU64 reduces all values across procs to all procs from orig into dest using given operation.
 // IMPORTANT: orig and dest must be different slices
 // To do an in-place operation, set orig to nil
 func (cm *Comm) AllReduceU64(op Op, dest, orig []uint64) error {
+	cm.countMetric("AllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceU64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceU64"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
 	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+		sendbuf = bufPtr(orig)
 	} else {
 		sendbuf = C.MPI_IN_PLACE
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.UINT64, op.ToC(), cm.comm), "AllReduceU64")
 }
 
+// IAllReduceU64 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceU64(op Op, dest, orig []uint64) (*Request, error) {
+	cm.countMetric("IAllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "IAllReduceU64"); err != nil {
+		return nil, err
+	}
+	if err := cm.checkOp(op, "IAllReduceU64"); err != nil {
+		return nil, err
+	}
+	r := newRequest(bufPtr(dest))
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = bufPtr(orig)
+		if sendbuf != nil {
+			r.pin.Pin(sendbuf)
+		}
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := bufPtr(dest)
+	return r, Error(C.MPI_Iallreduce(sendbuf, recvbuf, C.int(len(dest)), C.UINT64, op.ToC(), cm.comm, &r.req), "IAllReduceU64")
+}
+
+// ScanU64 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanU64(op Op, dest, orig []uint64) error {
+	cm.countMetric("Scan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ScanU64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ScanU64"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ScanU64: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	return Error(C.MPI_Scan(bufPtr(orig), bufPtr(dest), C.int(len(orig)), C.UINT64, op.ToC(), cm.comm), "ScanU64")
+}
+
+// ExScanU64 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanU64(op Op, dest, orig []uint64) error {
+	cm.countMetric("ExScan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ExScanU64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ExScanU64"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ExScanU64: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	recvbuf := bufPtr(dest)
+	if cm.Rank() == 0 {
+		recvbuf = bufPtr(make([]uint64, len(orig)))
+	}
+	return Error(C.MPI_Exscan(bufPtr(orig), recvbuf, C.int(len(orig)), C.UINT64, op.ToC(), cm.comm), "ExScanU64")
+}
+
 // GatherU64 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
-// recvbuf is ignored on all procs except toProc.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherU64(toProc int, dest, orig []uint64) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Gather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	var recvbuf unsafe.Pointer
+	var derr error
+	if cm.Rank() == toProc {
+		np := cm.Size()
+		if len(dest) < np*len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.GatherU64: len(dest) %d < number of procs %d * len(orig) %d", len(dest), np, len(orig))
+			recvbuf = bufPtr(make([]uint64, np*len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.UINT64, recvbuf, C.int(len(orig)), C.UINT64, C.int(toProc), cm.comm), "GatherU64")
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+// GatherInPlaceU64 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceU64(toProc int, buf []uint64) error {
+	cm.countMetric("GatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	isTo := cm.Rank() == toProc
+	np := cm.Size()
+	var err error
+	if isTo && len(buf)%np != 0 {
+		err = errorf("mpi.GatherInPlaceU64: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	if err = cm.rootCheck(toProc, err, "GatherInPlaceU64"); err != nil {
+		return err
+	}
+	ptr := bufPtr(buf)
+	if !isTo {
+		return Error(C.MPI_Gather(ptr, C.int(len(buf)), C.UINT64, nil, 0, C.UINT64, C.int(toProc), cm.comm), "GatherInPlaceU64")
+	}
+	n := len(buf) / np
+	return Error(C.MPI_Gather(C.MPI_IN_PLACE, 0, C.UINT64, ptr, C.int(n), C.UINT64, C.int(toProc), cm.comm), "GatherInPlaceU64")
+}
+
+// GathervU64 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervU64(toProc int, dest, orig []uint64, counts, displs []int) error {
+	cm.countMetric("Gatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	isTo := cm.Rank() == toProc
+	var err error
+	if isTo {
+		np := cm.Size()
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.GathervU64: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[toProc] != len(orig):
+			err = errorf("mpi.GathervU64: counts[%d] %d is not equal to len(orig): %d", toProc, counts[toProc], len(orig))
+		default:
+			err = checkCounts("GathervU64", "dest", len(dest), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(toProc, err, "GathervU64"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var rc, rd *C.int
+	if isTo {
+		recvbuf = bufPtr(dest)
+		cc, cd := cInts(counts), cInts(displs)
+		rc, rd = &cc[0], &cd[0]
 	}
-	return Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.UINT64, recvbuf, C.int(len(orig)), C.UINT64, C.int(toProc), cm.comm), "GatherU64")
+	return Error(C.MPI_Gatherv(sendbuf, C.int(len(orig)), C.UINT64, recvbuf, rc, rd, C.UINT64, C.int(toProc), cm.comm), "GathervU64")
 }
 
 // AllGatherU64 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) AllGatherU64(dest, orig []uint64) error {
-	sendbuf := unsafe.Pointer(&orig[0])
-	recvbuf := unsafe.Pointer(&dest[0])
+	cm.countMetric("AllGather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.UINT64, recvbuf, C.int(len(orig)), C.UINT64, cm.comm), "GatherU64")
 }
 
+// AllGathervU64 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervU64(dest, orig []uint64, counts, displs []int) error {
+	cm.countMetric("AllGatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if displs == nil {
+		displs, _ = Displacements(counts)
+	}
+	// counts and displs are the same on all procs, so all procs return together.
+	if len(counts) != np || len(displs) != np {
+		return errorf("mpi.AllGathervU64: counts and displacements must have length equal to number of procs: %d", np)
+	}
+	if err := checkCounts("AllGathervU64", "dest", len(dest), counts, displs); err != nil {
+		return err
+	}
+	// but len(orig) is only known locally, so a mismatch is sent from
+	// scratch, to keep the other procs from blocking, and returned after.
+	rank := cm.Rank()
+	send := orig
+	var oerr error
+	if counts[rank] != len(orig) {
+		oerr = errorf("mpi.AllGathervU64: counts[%d] %d is not equal to len(orig): %d", rank, counts[rank], len(orig))
+		send = make([]uint64, counts[rank])
+		copy(send, orig)
+	}
+	sendbuf := bufPtr(send)
+	recvbuf := bufPtr(dest)
+	cc, cd := cInts(counts), cInts(displs)
+	err := Error(C.MPI_Allgatherv(sendbuf, C.int(len(send)), C.UINT64, recvbuf, &cc[0], &cd[0], C.UINT64, cm.comm), "AllGathervU64")
+	if err != nil {
+		return err
+	}
+	return oerr
+}
+
+// AllGatherInPlaceU64 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceU64(buf []uint64) error {
+	cm.countMetric("AllGatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(buf)%np != 0 {
+		return errorf("mpi.AllGatherInPlaceU64: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	n := len(buf) / np
+	recvbuf := bufPtr(buf)
+	return Error(C.MPI_Allgather(C.MPI_IN_PLACE, 0, C.UINT64, recvbuf, C.int(n), C.UINT64, cm.comm), "AllGatherInPlaceU64")
+}
+
 // ScatterU64 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
-// sendbuf is ignored on all procs except fmProc.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterU64(fmProc int, dest, orig []uint64) error {
+	cm.countMetric("Scatter", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	isFrom := cm.Rank() == fmProc
+	var err error
+	if isFrom && len(orig) < cm.Size()*len(dest) {
+		err = errorf("mpi.ScatterU64: len(orig) %d < number of procs %d * len(dest) %d", len(orig), cm.Size(), len(dest))
+	}
+	if err = cm.rootCheck(fmProc, err, "ScatterU64"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
-	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+	if isFrom {
+		sendbuf = bufPtr(orig)
+	}
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.UINT64, recvbuf, C.int(len(dest)), C.UINT64, C.int(fmProc), cm.comm), "ScatterU64")
+}
+
+// ScattervU64 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervU64(fmProc int, dest, orig []uint64, counts, displs []int) error {
+	cm.countMetric("Scatterv", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	rank := cm.Rank()
+	np := cm.Size()
+	isFrom := rank == fmProc
+	var err error
+	if isFrom {
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.ScattervU64: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[fmProc] > len(dest):
+			err = errorf("mpi.ScattervU64: len(dest) %d is less than counts[%d]: %d", len(dest), fmProc, counts[fmProc])
+		default:
+			err = checkCounts("ScattervU64", "orig", len(orig), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(fmProc, err, "ScattervU64"); err != nil {
+		return err
+	}
+	// a short dest on another proc cannot be reported before the collective,
+	// so that proc receives into scratch and returns the error after.
+	recv := dest
+	var derr error
+	if !isFrom && len(counts) == np && counts[rank] > len(dest) {
+		derr = errorf("mpi.ScattervU64: len(dest) %d is less than counts[%d]: %d", len(dest), rank, counts[rank])
+		recv = make([]uint64, counts[rank])
+	}
+	var sendbuf unsafe.Pointer
+	recvbuf := bufPtr(recv)
+	var sc, sd *C.int
+	if isFrom {
+		sendbuf = bufPtr(orig)
+		cc, cd := cInts(counts), cInts(displs)
+		sc, sd = &cc[0], &cd[0]
+	}
+	err = Error(C.MPI_Scatterv(sendbuf, sc, sd, C.UINT64, recvbuf, C.int(len(recv)), C.UINT64, C.int(fmProc), cm.comm), "ScattervU64")
+	if err != nil {
+		return err
+	}
+	return derr
+}
+
+// AllToAllU64 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllU64(dest, orig []uint64) error {
+	cm.countMetric("AllToAll", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(orig)%np != 0 || len(dest)%np != 0 {
+		return errorf("mpi.AllToAllU64: len(orig) %d and len(dest) %d must be evenly divisible by number of procs %d", len(orig), len(dest), np)
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllToAllU64: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	n := len(orig) / np
+	return Error(C.MPI_Alltoall(bufPtr(orig), C.int(n), C.UINT64, bufPtr(dest), C.int(n), C.UINT64, cm.comm), "AllToAllU64")
+}
+
+// AllToAllvU64 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvU64(dest, orig []uint64, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	cm.countMetric("AllToAllv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if sendDispls == nil {
+		sendDispls, _ = Displacements(sendCounts)
+	}
+	if recvDispls == nil {
+		recvDispls, _ = Displacements(recvCounts)
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.UINT64, recvbuf, C.int(len(dest)), C.UINT64, C.int(fmProc), cm.comm), "GatherU64")
+	// the counts differ across procs, so any bad ones are reported on all procs.
+	var err error
+	if len(sendCounts) != np || len(recvCounts) != np || len(sendDispls) != np || len(recvDispls) != np {
+		err = errorf("mpi.AllToAllvU64: counts and displacements must have length equal to number of procs: %d", np)
+	} else if err = checkCounts("AllToAllvU64", "orig", len(orig), sendCounts, sendDispls); err == nil {
+		err = checkCounts("AllToAllvU64", "dest", len(dest), recvCounts, recvDispls)
+	}
+	if err = cm.allCheck(err, "AllToAllvU64"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	sc, sd := cInts(sendCounts), cInts(sendDispls)
+	rc, rd := cInts(recvCounts), cInts(recvDispls)
+	return Error(C.MPI_Alltoallv(sendbuf, &sc[0], &sd[0], C.UINT64, recvbuf, &rc[0], &rd[0], C.UINT64, cm.comm), "AllToAllvU64")
 }
 
 // SendI32 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendI32(toProc int, tag int, vals []int32) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Send", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Send(buf, C.int(len(vals)), C.INT32, C.int(toProc), C.int(tag), cm.comm), "SendI32")
 }
 
-// Recv64I32 receives values from proc fmProc, using given unique tag identifier
+// RecvI32 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvI32(fmProc int, tag int, vals []int32) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Recv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.INT32, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvI32")
 }
 
+// IsendI32 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendI32(toProc int, tag int, vals []int32) (*Request, error) {
+	cm.countMetric("Isend", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRequest(bufPtr(vals))
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Isend(buf, C.int(len(vals)), C.INT32, C.int(toProc), C.int(tag), cm.comm, &r.req), "IsendI32")
+}
+
+// IrecvI32 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvI32(fmProc int, tag int, vals []int32) (*Request, error) {
+	cm.countMetric("Irecv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRecvRequest(bufPtr(vals), C.INT32)
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Irecv(buf, C.int(len(vals)), C.INT32, C.int(fmProc), C.int(tag), cm.comm, &r.req), "IrecvI32")
+}
+
 // BcastI32 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastI32(fmProc int, vals []int32) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Bcast", len(vals)*int(unsafe.Sizeof(vals[0])))
+	defer cm.enterCollective()()
+	buf := bufPtr(vals)
 	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.INT32, C.int(fmProc), cm.comm), "BcastI32")
 }
 
 // ReduceI32 reduces all values across procs to toProc in orig to dest using given operation.
-// recvbuf is ignored on all procs except toProc.
+// If VerifyReduce is set, the result is checked against an AllReduce.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceI32(toProc int, op Op, dest, orig []int32) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Reduce", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ReduceI32"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ReduceI32"); err != nil {
+		return err
+	}
+	isTo := cm.Rank() == toProc
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var derr error
+	if isTo {
+		if len(dest) < len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.ReduceI32: len(dest) %d < len(orig) %d", len(dest), len(orig))
+			recvbuf = bufPtr(make([]int32, len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
 	}
-	return Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(dest)), C.INT32, op.ToC(), C.int(toProc), cm.comm), "ReduceI32")
+	err := Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(orig)), C.INT32, op.ToC(), C.int(toProc), cm.comm), "ReduceI32")
+	if err == nil && VerifyReduce {
+		ver := make([]int32, len(orig))
+		err = Error(C.MPI_Allreduce(sendbuf, bufPtr(ver), C.int(len(orig)), C.INT32, op.ToC(), cm.comm), "ReduceI32")
+		if err == nil && isTo && derr == nil {
+			err = verifyReduce(dest, ver, "ReduceI32")
+		}
+	}
+	if derr != nil {
+		return derr
+	}
+	return err
 }
 
 // AllReduceI32 reduces all values across procs to all procs from orig into dest using given operation.
 // IMPORTANT: orig and dest must be different slices
 // To do an in-place operation, set orig to nil
 func (cm *Comm) AllReduceI32(op Op, dest, orig []int32) error {
+	cm.countMetric("AllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceI32"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceI32"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
 	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+		sendbuf = bufPtr(orig)
 	} else {
 		sendbuf = C.MPI_IN_PLACE
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.INT32, op.ToC(), cm.comm), "AllReduceI32")
 }
 
+// IAllReduceI32 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceI32(op Op, dest, orig []int32) (*Request, error) {
+	cm.countMetric("IAllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "IAllReduceI32"); err != nil {
+		return nil, err
+	}
+	if err := cm.checkOp(op, "IAllReduceI32"); err != nil {
+		return nil, err
+	}
+	r := newRequest(bufPtr(dest))
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = bufPtr(orig)
+		if sendbuf != nil {
+			r.pin.Pin(sendbuf)
+		}
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := bufPtr(dest)
+	return r, Error(C.MPI_Iallreduce(sendbuf, recvbuf, C.int(len(dest)), C.INT32, op.ToC(), cm.comm, &r.req), "IAllReduceI32")
+}
+
+// ScanI32 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanI32(op Op, dest, orig []int32) error {
+	cm.countMetric("Scan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ScanI32"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ScanI32"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ScanI32: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	return Error(C.MPI_Scan(bufPtr(orig), bufPtr(dest), C.int(len(orig)), C.INT32, op.ToC(), cm.comm), "ScanI32")
+}
+
+// ExScanI32 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanI32(op Op, dest, orig []int32) error {
+	cm.countMetric("ExScan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ExScanI32"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ExScanI32"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ExScanI32: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	recvbuf := bufPtr(dest)
+	if cm.Rank() == 0 {
+		recvbuf = bufPtr(make([]int32, len(orig)))
+	}
+	return Error(C.MPI_Exscan(bufPtr(orig), recvbuf, C.int(len(orig)), C.INT32, op.ToC(), cm.comm), "ExScanI32")
+}
+
 // GatherI32 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
-// recvbuf is ignored on all procs except toProc.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherI32(toProc int, dest, orig []int32) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Gather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	var recvbuf unsafe.Pointer
+	var derr error
+	if cm.Rank() == toProc {
+		np := cm.Size()
+		if len(dest) < np*len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.GatherI32: len(dest) %d < number of procs %d * len(orig) %d", len(dest), np, len(orig))
+			recvbuf = bufPtr(make([]int32, np*len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.INT32, recvbuf, C.int(len(orig)), C.INT32, C.int(toProc), cm.comm), "GatherI32")
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+// GatherInPlaceI32 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceI32(toProc int, buf []int32) error {
+	cm.countMetric("GatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	isTo := cm.Rank() == toProc
+	np := cm.Size()
+	var err error
+	if isTo && len(buf)%np != 0 {
+		err = errorf("mpi.GatherInPlaceI32: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	if err = cm.rootCheck(toProc, err, "GatherInPlaceI32"); err != nil {
+		return err
+	}
+	ptr := bufPtr(buf)
+	if !isTo {
+		return Error(C.MPI_Gather(ptr, C.int(len(buf)), C.INT32, nil, 0, C.INT32, C.int(toProc), cm.comm), "GatherInPlaceI32")
+	}
+	n := len(buf) / np
+	return Error(C.MPI_Gather(C.MPI_IN_PLACE, 0, C.INT32, ptr, C.int(n), C.INT32, C.int(toProc), cm.comm), "GatherInPlaceI32")
+}
+
+// GathervI32 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervI32(toProc int, dest, orig []int32, counts, displs []int) error {
+	cm.countMetric("Gatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	isTo := cm.Rank() == toProc
+	var err error
+	if isTo {
+		np := cm.Size()
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.GathervI32: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[toProc] != len(orig):
+			err = errorf("mpi.GathervI32: counts[%d] %d is not equal to len(orig): %d", toProc, counts[toProc], len(orig))
+		default:
+			err = checkCounts("GathervI32", "dest", len(dest), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(toProc, err, "GathervI32"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var rc, rd *C.int
+	if isTo {
+		recvbuf = bufPtr(dest)
+		cc, cd := cInts(counts), cInts(displs)
+		rc, rd = &cc[0], &cd[0]
 	}
-	return Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.INT32, recvbuf, C.int(len(orig)), C.INT32, C.int(toProc), cm.comm), "GatherI32")
+	return Error(C.MPI_Gatherv(sendbuf, C.int(len(orig)), C.INT32, recvbuf, rc, rd, C.INT32, C.int(toProc), cm.comm), "GathervI32")
 }
 
 // AllGatherI32 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) AllGatherI32(dest, orig []int32) error {
-	sendbuf := unsafe.Pointer(&orig[0])
-	recvbuf := unsafe.Pointer(&dest[0])
+	cm.countMetric("AllGather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.INT32, recvbuf, C.int(len(orig)), C.INT32, cm.comm), "GatherI32")
 }
 
+// AllGathervI32 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervI32(dest, orig []int32, counts, displs []int) error {
+	cm.countMetric("AllGatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if displs == nil {
+		displs, _ = Displacements(counts)
+	}
+	// counts and displs are the same on all procs, so all procs return together.
+	if len(counts) != np || len(displs) != np {
+		return errorf("mpi.AllGathervI32: counts and displacements must have length equal to number of procs: %d", np)
+	}
+	if err := checkCounts("AllGathervI32", "dest", len(dest), counts, displs); err != nil {
+		return err
+	}
+	// but len(orig) is only known locally, so a mismatch is sent from
+	// scratch, to keep the other procs from blocking, and returned after.
+	rank := cm.Rank()
+	send := orig
+	var oerr error
+	if counts[rank] != len(orig) {
+		oerr = errorf("mpi.AllGathervI32: counts[%d] %d is not equal to len(orig): %d", rank, counts[rank], len(orig))
+		send = make([]int32, counts[rank])
+		copy(send, orig)
+	}
+	sendbuf := bufPtr(send)
+	recvbuf := bufPtr(dest)
+	cc, cd := cInts(counts), cInts(displs)
+	err := Error(C.MPI_Allgatherv(sendbuf, C.int(len(send)), C.INT32, recvbuf, &cc[0], &cd[0], C.INT32, cm.comm), "AllGathervI32")
+	if err != nil {
+		return err
+	}
+	return oerr
+}
+
+// AllGatherInPlaceI32 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceI32(buf []int32) error {
+	cm.countMetric("AllGatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(buf)%np != 0 {
+		return errorf("mpi.AllGatherInPlaceI32: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	n := len(buf) / np
+	recvbuf := bufPtr(buf)
+	return Error(C.MPI_Allgather(C.MPI_IN_PLACE, 0, C.INT32, recvbuf, C.int(n), C.INT32, cm.comm), "AllGatherInPlaceI32")
+}
+
 // ScatterI32 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
-// sendbuf is ignored on all procs except fmProc.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterI32(fmProc int, dest, orig []int32) error {
+	cm.countMetric("Scatter", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	isFrom := cm.Rank() == fmProc
+	var err error
+	if isFrom && len(orig) < cm.Size()*len(dest) {
+		err = errorf("mpi.ScatterI32: len(orig) %d < number of procs %d * len(dest) %d", len(orig), cm.Size(), len(dest))
+	}
+	if err = cm.rootCheck(fmProc, err, "ScatterI32"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
-	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+	if isFrom {
+		sendbuf = bufPtr(orig)
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.INT32, recvbuf, C.int(len(dest)), C.INT32, C.int(fmProc), cm.comm), "GatherI32")
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.INT32, recvbuf, C.int(len(dest)), C.INT32, C.int(fmProc), cm.comm), "ScatterI32")
+}
+
+// ScattervI32 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervI32(fmProc int, dest, orig []int32, counts, displs []int) error {
+	cm.countMetric("Scatterv", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	rank := cm.Rank()
+	np := cm.Size()
+	isFrom := rank == fmProc
+	var err error
+	if isFrom {
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.ScattervI32: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[fmProc] > len(dest):
+			err = errorf("mpi.ScattervI32: len(dest) %d is less than counts[%d]: %d", len(dest), fmProc, counts[fmProc])
+		default:
+			err = checkCounts("ScattervI32", "orig", len(orig), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(fmProc, err, "ScattervI32"); err != nil {
+		return err
+	}
+	// a short dest on another proc cannot be reported before the collective,
+	// so that proc receives into scratch and returns the error after.
+	recv := dest
+	var derr error
+	if !isFrom && len(counts) == np && counts[rank] > len(dest) {
+		derr = errorf("mpi.ScattervI32: len(dest) %d is less than counts[%d]: %d", len(dest), rank, counts[rank])
+		recv = make([]int32, counts[rank])
+	}
+	var sendbuf unsafe.Pointer
+	recvbuf := bufPtr(recv)
+	var sc, sd *C.int
+	if isFrom {
+		sendbuf = bufPtr(orig)
+		cc, cd := cInts(counts), cInts(displs)
+		sc, sd = &cc[0], &cd[0]
+	}
+	err = Error(C.MPI_Scatterv(sendbuf, sc, sd, C.INT32, recvbuf, C.int(len(recv)), C.INT32, C.int(fmProc), cm.comm), "ScattervI32")
+	if err != nil {
+		return err
+	}
+	return derr
+}
+
+// AllToAllI32 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllI32(dest, orig []int32) error {
+	cm.countMetric("AllToAll", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(orig)%np != 0 || len(dest)%np != 0 {
+		return errorf("mpi.AllToAllI32: len(orig) %d and len(dest) %d must be evenly divisible by number of procs %d", len(orig), len(dest), np)
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllToAllI32: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	n := len(orig) / np
+	return Error(C.MPI_Alltoall(bufPtr(orig), C.int(n), C.INT32, bufPtr(dest), C.int(n), C.INT32, cm.comm), "AllToAllI32")
+}
+
+// AllToAllvI32 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvI32(dest, orig []int32, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	cm.countMetric("AllToAllv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if sendDispls == nil {
+		sendDispls, _ = Displacements(sendCounts)
+	}
+	if recvDispls == nil {
+		recvDispls, _ = Displacements(recvCounts)
+	}
+	// the counts differ across procs, so any bad ones are reported on all procs.
+	var err error
+	if len(sendCounts) != np || len(recvCounts) != np || len(sendDispls) != np || len(recvDispls) != np {
+		err = errorf("mpi.AllToAllvI32: counts and displacements must have length equal to number of procs: %d", np)
+	} else if err = checkCounts("AllToAllvI32", "orig", len(orig), sendCounts, sendDispls); err == nil {
+		err = checkCounts("AllToAllvI32", "dest", len(dest), recvCounts, recvDispls)
+	}
+	if err = cm.allCheck(err, "AllToAllvI32"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	sc, sd := cInts(sendCounts), cInts(sendDispls)
+	rc, rd := cInts(recvCounts), cInts(recvDispls)
+	return Error(C.MPI_Alltoallv(sendbuf, &sc[0], &sd[0], C.INT32, recvbuf, &rc[0], &rd[0], C.INT32, cm.comm), "AllToAllvI32")
 }
 
 // SendU32 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendU32(toProc int, tag int, vals []uint32) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Send", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Send(buf, C.int(len(vals)), C.UINT32, C.int(toProc), C.int(tag), cm.comm), "SendU32")
 }
 
-// Recv64U32 receives values from proc fmProc, using given unique tag identifier
+// RecvU32 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvU32(fmProc int, tag int, vals []uint32) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Recv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.UINT32, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvU32")
 }
 
+// IsendU32 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendU32(toProc int, tag int, vals []uint32) (*Request, error) {
+	cm.countMetric("Isend", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRequest(bufPtr(vals))
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Isend(buf, C.int(len(vals)), C.UINT32, C.int(toProc), C.int(tag), cm.comm, &r.req), "IsendU32")
+}
+
+// IrecvU32 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvU32(fmProc int, tag int, vals []uint32) (*Request, error) {
+	cm.countMetric("Irecv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRecvRequest(bufPtr(vals), C.UINT32)
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Irecv(buf, C.int(len(vals)), C.UINT32, C.int(fmProc), C.int(tag), cm.comm, &r.req), "IrecvU32")
+}
+
 // BcastU32 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastU32(fmProc int, vals []uint32) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Bcast", len(vals)*int(unsafe.Sizeof(vals[0])))
+	defer cm.enterCollective()()
+	buf := bufPtr(vals)
 	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.UINT32, C.int(fmProc), cm.comm), "BcastU32")
 }
 
 // ReduceU32 reduces all values across procs to toProc in orig to dest using given operation.
-// recvbuf is ignored on all procs except toProc.
+// If VerifyReduce is set, the result is checked against an AllReduce.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceU32(toProc int, op Op, dest, orig []uint32) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Reduce", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ReduceU32"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ReduceU32"); err != nil {
+		return err
+	}
+	isTo := cm.Rank() == toProc
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var derr error
+	if isTo {
+		if len(dest) < len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.ReduceU32: len(dest) %d < len(orig) %d", len(dest), len(orig))
+			recvbuf = bufPtr(make([]uint32, len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(orig)), C.UINT32, op.ToC(), C.int(toProc), cm.comm), "ReduceU32")
+	if err == nil && VerifyReduce {
+		ver := make([]uint32, len(orig))
+		err = Error(C.MPI_Allreduce(sendbuf, bufPtr(ver), C.int(len(orig)), C.UINT32, op.ToC(), cm.comm), "ReduceU32")
+		if err == nil && isTo && derr == nil {
+			err = verifyReduce(dest, ver, "ReduceU32")
+		}
 	}
-	return Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(dest)), C.UINT32, op.ToC(), C.int(toProc), cm.comm), "ReduceU32")
+	if derr != nil {
+		return derr
+	}
+	return err
 }
 
 // AllReduceU32 reduces all values across procs to all procs from orig into dest using given operation.
 // IMPORTANT: orig and dest must be different slices
 // To do an in-place operation, set orig to nil
 func (cm *Comm) AllReduceU32(op Op, dest, orig []uint32) error {
+	cm.countMetric("AllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceU32"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceU32"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
 	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+		sendbuf = bufPtr(orig)
 	} else {
 		sendbuf = C.MPI_IN_PLACE
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.UINT32, op.ToC(), cm.comm), "AllReduceU32")
 }
 
-// GatherU32 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
+// IAllReduceU32 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceU32(op Op, dest, orig []uint32) (*Request, error) {
+	cm.countMetric("IAllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "IAllReduceU32"); err != nil {
+		return nil, err
+	}
+	if err := cm.checkOp(op, "IAllReduceU32"); err != nil {
+		return nil, err
+	}
+	r := newRequest(bufPtr(dest))
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = bufPtr(orig)
+		if sendbuf != nil {
+			r.pin.Pin(sendbuf)
+		}
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := bufPtr(dest)
+	return r, Error(C.MPI_Iallreduce(sendbuf, recvbuf, C.int(len(dest)), C.UINT32, op.ToC(), cm.comm, &r.req), "IAllReduceU32")
+}
+
+// ScanU32 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanU32(op Op, dest, orig []uint32) error {
+	cm.countMetric("Scan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ScanU32"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ScanU32"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ScanU32: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	return Error(C.MPI_Scan(bufPtr(orig), bufPtr(dest), C.int(len(orig)), C.UINT32, op.ToC(), cm.comm), "ScanU32")
+}
+
+// ExScanU32 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanU32(op Op, dest, orig []uint32) error {
+	cm.countMetric("ExScan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ExScanU32"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ExScanU32"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ExScanU32: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	recvbuf := bufPtr(dest)
+	if cm.Rank() == 0 {
+		recvbuf = bufPtr(make([]uint32, len(orig)))
+	}
+	return Error(C.MPI_Exscan(bufPtr(orig), recvbuf, C.int(len(orig)), C.UINT32, op.ToC(), cm.comm), "ExScanU32")
+}
+
+// GatherU32 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
-// recvbuf is ignored on all procs except toProc.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherU32(toProc int, dest, orig []uint32) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Gather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	var recvbuf unsafe.Pointer
+	var derr error
+	if cm.Rank() == toProc {
+		np := cm.Size()
+		if len(dest) < np*len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.GatherU32: len(dest) %d < number of procs %d * len(orig) %d", len(dest), np, len(orig))
+			recvbuf = bufPtr(make([]uint32, np*len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.UINT32, recvbuf, C.int(len(orig)), C.UINT32, C.int(toProc), cm.comm), "GatherU32")
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+// GatherInPlaceU32 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceU32(toProc int, buf []uint32) error {
+	cm.countMetric("GatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	isTo := cm.Rank() == toProc
+	np := cm.Size()
+	var err error
+	if isTo && len(buf)%np != 0 {
+		err = errorf("mpi.GatherInPlaceU32: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	if err = cm.rootCheck(toProc, err, "GatherInPlaceU32"); err != nil {
+		return err
+	}
+	ptr := bufPtr(buf)
+	if !isTo {
+		return Error(C.MPI_Gather(ptr, C.int(len(buf)), C.UINT32, nil, 0, C.UINT32, C.int(toProc), cm.comm), "GatherInPlaceU32")
+	}
+	n := len(buf) / np
+	return Error(C.MPI_Gather(C.MPI_IN_PLACE, 0, C.UINT32, ptr, C.int(n), C.UINT32, C.int(toProc), cm.comm), "GatherInPlaceU32")
+}
+
+// GathervU32 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervU32(toProc int, dest, orig []uint32, counts, displs []int) error {
+	cm.countMetric("Gatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	isTo := cm.Rank() == toProc
+	var err error
+	if isTo {
+		np := cm.Size()
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.GathervU32: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[toProc] != len(orig):
+			err = errorf("mpi.GathervU32: counts[%d] %d is not equal to len(orig): %d", toProc, counts[toProc], len(orig))
+		default:
+			err = checkCounts("GathervU32", "dest", len(dest), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(toProc, err, "GathervU32"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var rc, rd *C.int
+	if isTo {
+		recvbuf = bufPtr(dest)
+		cc, cd := cInts(counts), cInts(displs)
+		rc, rd = &cc[0], &cd[0]
 	}
-	return Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.UINT32, recvbuf, C.int(len(orig)), C.UINT32, C.int(toProc), cm.comm), "GatherU32")
+	return Error(C.MPI_Gatherv(sendbuf, C.int(len(orig)), C.UINT32, recvbuf, rc, rd, C.UINT32, C.int(toProc), cm.comm), "GathervU32")
 }
 
 // AllGatherU32 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) AllGatherU32(dest, orig []uint32) error {
-	sendbuf := unsafe.Pointer(&orig[0])
-	recvbuf := unsafe.Pointer(&dest[0])
+	cm.countMetric("AllGather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.UINT32, recvbuf, C.int(len(orig)), C.UINT32, cm.comm), "GatherU32")
 }
 
+// AllGathervU32 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervU32(dest, orig []uint32, counts, displs []int) error {
+	cm.countMetric("AllGatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if displs == nil {
+		displs, _ = Displacements(counts)
+	}
+	// counts and displs are the same on all procs, so all procs return together.
+	if len(counts) != np || len(displs) != np {
+		return errorf("mpi.AllGathervU32: counts and displacements must have length equal to number of procs: %d", np)
+	}
+	if err := checkCounts("AllGathervU32", "dest", len(dest), counts, displs); err != nil {
+		return err
+	}
+	// but len(orig) is only known locally, so a mismatch is sent from
+	// scratch, to keep the other procs from blocking, and returned after.
+	rank := cm.Rank()
+	send := orig
+	var oerr error
+	if counts[rank] != len(orig) {
+		oerr = errorf("mpi.AllGathervU32: counts[%d] %d is not equal to len(orig): %d", rank, counts[rank], len(orig))
+		send = make([]uint32, counts[rank])
+		copy(send, orig)
+	}
+	sendbuf := bufPtr(send)
+	recvbuf := bufPtr(dest)
+	cc, cd := cInts(counts), cInts(displs)
+	err := Error(C.MPI_Allgatherv(sendbuf, C.int(len(send)), C.UINT32, recvbuf, &cc[0], &cd[0], C.UINT32, cm.comm), "AllGathervU32")
+	if err != nil {
+		return err
+	}
+	return oerr
+}
+
+// AllGatherInPlaceU32 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceU32(buf []uint32) error {
+	cm.countMetric("AllGatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(buf)%np != 0 {
+		return errorf("mpi.AllGatherInPlaceU32: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	n := len(buf) / np
+	recvbuf := bufPtr(buf)
+	return Error(C.MPI_Allgather(C.MPI_IN_PLACE, 0, C.UINT32, recvbuf, C.int(n), C.UINT32, cm.comm), "AllGatherInPlaceU32")
+}
+
 // ScatterU32 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
-// sendbuf is ignored on all procs except fmProc.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterU32(fmProc int, dest, orig []uint32) error {
+	cm.countMetric("Scatter", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	isFrom := cm.Rank() == fmProc
+	var err error
+	if isFrom && len(orig) < cm.Size()*len(dest) {
+		err = errorf("mpi.ScatterU32: len(orig) %d < number of procs %d * len(dest) %d", len(orig), cm.Size(), len(dest))
+	}
+	if err = cm.rootCheck(fmProc, err, "ScatterU32"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
-	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+	if isFrom {
+		sendbuf = bufPtr(orig)
+	}
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.UINT32, recvbuf, C.int(len(dest)), C.UINT32, C.int(fmProc), cm.comm), "ScatterU32")
+}
+
+// ScattervU32 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervU32(fmProc int, dest, orig []uint32, counts, displs []int) error {
+	cm.countMetric("Scatterv", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	rank := cm.Rank()
+	np := cm.Size()
+	isFrom := rank == fmProc
+	var err error
+	if isFrom {
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.ScattervU32: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[fmProc] > len(dest):
+			err = errorf("mpi.ScattervU32: len(dest) %d is less than counts[%d]: %d", len(dest), fmProc, counts[fmProc])
+		default:
+			err = checkCounts("ScattervU32", "orig", len(orig), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(fmProc, err, "ScattervU32"); err != nil {
+		return err
+	}
+	// a short dest on another proc cannot be reported before the collective,
+	// so that proc receives into scratch and returns the error after.
+	recv := dest
+	var derr error
+	if !isFrom && len(counts) == np && counts[rank] > len(dest) {
+		derr = errorf("mpi.ScattervU32: len(dest) %d is less than counts[%d]: %d", len(dest), rank, counts[rank])
+		recv = make([]uint32, counts[rank])
+	}
+	var sendbuf unsafe.Pointer
+	recvbuf := bufPtr(recv)
+	var sc, sd *C.int
+	if isFrom {
+		sendbuf = bufPtr(orig)
+		cc, cd := cInts(counts), cInts(displs)
+		sc, sd = &cc[0], &cd[0]
+	}
+	err = Error(C.MPI_Scatterv(sendbuf, sc, sd, C.UINT32, recvbuf, C.int(len(recv)), C.UINT32, C.int(fmProc), cm.comm), "ScattervU32")
+	if err != nil {
+		return err
+	}
+	return derr
+}
+
+// AllToAllU32 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllU32(dest, orig []uint32) error {
+	cm.countMetric("AllToAll", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(orig)%np != 0 || len(dest)%np != 0 {
+		return errorf("mpi.AllToAllU32: len(orig) %d and len(dest) %d must be evenly divisible by number of procs %d", len(orig), len(dest), np)
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllToAllU32: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	n := len(orig) / np
+	return Error(C.MPI_Alltoall(bufPtr(orig), C.int(n), C.UINT32, bufPtr(dest), C.int(n), C.UINT32, cm.comm), "AllToAllU32")
+}
+
+// AllToAllvU32 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvU32(dest, orig []uint32, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	cm.countMetric("AllToAllv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if sendDispls == nil {
+		sendDispls, _ = Displacements(sendCounts)
+	}
+	if recvDispls == nil {
+		recvDispls, _ = Displacements(recvCounts)
+	}
+	// the counts differ across procs, so any bad ones are reported on all procs.
+	var err error
+	if len(sendCounts) != np || len(recvCounts) != np || len(sendDispls) != np || len(recvDispls) != np {
+		err = errorf("mpi.AllToAllvU32: counts and displacements must have length equal to number of procs: %d", np)
+	} else if err = checkCounts("AllToAllvU32", "orig", len(orig), sendCounts, sendDispls); err == nil {
+		err = checkCounts("AllToAllvU32", "dest", len(dest), recvCounts, recvDispls)
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.UINT32, recvbuf, C.int(len(dest)), C.UINT32, C.int(fmProc), cm.comm), "GatherU32")
+	if err = cm.allCheck(err, "AllToAllvU32"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	sc, sd := cInts(sendCounts), cInts(sendDispls)
+	rc, rd := cInts(recvCounts), cInts(recvDispls)
+	return Error(C.MPI_Alltoallv(sendbuf, &sc[0], &sd[0], C.UINT32, recvbuf, &rc[0], &rd[0], C.UINT32, cm.comm), "AllToAllvU32")
 }
 
 // SendI16 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendI16(toProc int, tag int, vals []int16) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Send", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Send(buf, C.int(len(vals)), C.INT16, C.int(toProc), C.int(tag), cm.comm), "SendI16")
 }
 
-// Recv64I16 receives values from proc fmProc, using given unique tag identifier
+// RecvI16 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvI16(fmProc int, tag int, vals []int16) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Recv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.INT16, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvI16")
 }
 
+// IsendI16 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendI16(toProc int, tag int, vals []int16) (*Request, error) {
+	cm.countMetric("Isend", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRequest(bufPtr(vals))
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Isend(buf, C.int(len(vals)), C.INT16, C.int(toProc), C.int(tag), cm.comm, &r.req), "IsendI16")
+}
+
+// IrecvI16 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvI16(fmProc int, tag int, vals []int16) (*Request, error) {
+	cm.countMetric("Irecv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRecvRequest(bufPtr(vals), C.INT16)
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Irecv(buf, C.int(len(vals)), C.INT16, C.int(fmProc), C.int(tag), cm.comm, &r.req), "IrecvI16")
+}
+
 // BcastI16 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastI16(fmProc int, vals []int16) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Bcast", len(vals)*int(unsafe.Sizeof(vals[0])))
+	defer cm.enterCollective()()
+	buf := bufPtr(vals)
 	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.INT16, C.int(fmProc), cm.comm), "BcastI16")
 }
 
 // ReduceI16 reduces all values across procs to toProc in orig to dest using given operation.
-// recvbuf is ignored on all procs except toProc.
+// If VerifyReduce is set, the result is checked against an AllReduce.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceI16(toProc int, op Op, dest, orig []int16) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Reduce", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ReduceI16"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ReduceI16"); err != nil {
+		return err
+	}
+	isTo := cm.Rank() == toProc
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var derr error
+	if isTo {
+		if len(dest) < len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.ReduceI16: len(dest) %d < len(orig) %d", len(dest), len(orig))
+			recvbuf = bufPtr(make([]int16, len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
 	}
-	return Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(dest)), C.INT16, op.ToC(), C.int(toProc), cm.comm), "ReduceI16")
+	err := Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(orig)), C.INT16, op.ToC(), C.int(toProc), cm.comm), "ReduceI16")
+	if err == nil && VerifyReduce {
+		ver := make([]int16, len(orig))
+		err = Error(C.MPI_Allreduce(sendbuf, bufPtr(ver), C.int(len(orig)), C.INT16, op.ToC(), cm.comm), "ReduceI16")
+		if err == nil && isTo && derr == nil {
+			err = verifyReduce(dest, ver, "ReduceI16")
+		}
+	}
+	if derr != nil {
+		return derr
+	}
+	return err
 }
 
 // AllReduceI16 reduces all values across procs to all procs from orig into dest using given operation.
 // IMPORTANT: orig and dest must be different slices
 // To do an in-place operation, set orig to nil
 func (cm *Comm) AllReduceI16(op Op, dest, orig []int16) error {
+	cm.countMetric("AllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceI16"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceI16"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
 	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+		sendbuf = bufPtr(orig)
 	} else {
 		sendbuf = C.MPI_IN_PLACE
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.INT16, op.ToC(), cm.comm), "AllReduceI16")
 }
 
+// IAllReduceI16 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceI16(op Op, dest, orig []int16) (*Request, error) {
+	cm.countMetric("IAllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "IAllReduceI16"); err != nil {
+		return nil, err
+	}
+	if err := cm.checkOp(op, "IAllReduceI16"); err != nil {
+		return nil, err
+	}
+	r := newRequest(bufPtr(dest))
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = bufPtr(orig)
+		if sendbuf != nil {
+			r.pin.Pin(sendbuf)
+		}
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := bufPtr(dest)
+	return r, Error(C.MPI_Iallreduce(sendbuf, recvbuf, C.int(len(dest)), C.INT16, op.ToC(), cm.comm, &r.req), "IAllReduceI16")
+}
+
+// ScanI16 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanI16(op Op, dest, orig []int16) error {
+	cm.countMetric("Scan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ScanI16"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ScanI16"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ScanI16: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	return Error(C.MPI_Scan(bufPtr(orig), bufPtr(dest), C.int(len(orig)), C.INT16, op.ToC(), cm.comm), "ScanI16")
+}
+
+// ExScanI16 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanI16(op Op, dest, orig []int16) error {
+	cm.countMetric("ExScan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ExScanI16"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ExScanI16"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ExScanI16: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	recvbuf := bufPtr(dest)
+	if cm.Rank() == 0 {
+		recvbuf = bufPtr(make([]int16, len(orig)))
+	}
+	return Error(C.MPI_Exscan(bufPtr(orig), recvbuf, C.int(len(orig)), C.INT16, op.ToC(), cm.comm), "ExScanI16")
+}
+
 // GatherI16 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
-// recvbuf is ignored on all procs except toProc.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherI16(toProc int, dest, orig []int16) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Gather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	var recvbuf unsafe.Pointer
+	var derr error
+	if cm.Rank() == toProc {
+		np := cm.Size()
+		if len(dest) < np*len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.GatherI16: len(dest) %d < number of procs %d * len(orig) %d", len(dest), np, len(orig))
+			recvbuf = bufPtr(make([]int16, np*len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.INT16, recvbuf, C.int(len(orig)), C.INT16, C.int(toProc), cm.comm), "GatherI16")
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+// GatherInPlaceI16 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceI16(toProc int, buf []int16) error {
+	cm.countMetric("GatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	isTo := cm.Rank() == toProc
+	np := cm.Size()
+	var err error
+	if isTo && len(buf)%np != 0 {
+		err = errorf("mpi.GatherInPlaceI16: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	if err = cm.rootCheck(toProc, err, "GatherInPlaceI16"); err != nil {
+		return err
+	}
+	ptr := bufPtr(buf)
+	if !isTo {
+		return Error(C.MPI_Gather(ptr, C.int(len(buf)), C.INT16, nil, 0, C.INT16, C.int(toProc), cm.comm), "GatherInPlaceI16")
+	}
+	n := len(buf) / np
+	return Error(C.MPI_Gather(C.MPI_IN_PLACE, 0, C.INT16, ptr, C.int(n), C.INT16, C.int(toProc), cm.comm), "GatherInPlaceI16")
+}
+
+// GathervI16 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervI16(toProc int, dest, orig []int16, counts, displs []int) error {
+	cm.countMetric("Gatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	isTo := cm.Rank() == toProc
+	var err error
+	if isTo {
+		np := cm.Size()
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.GathervI16: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[toProc] != len(orig):
+			err = errorf("mpi.GathervI16: counts[%d] %d is not equal to len(orig): %d", toProc, counts[toProc], len(orig))
+		default:
+			err = checkCounts("GathervI16", "dest", len(dest), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(toProc, err, "GathervI16"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var rc, rd *C.int
+	if isTo {
+		recvbuf = bufPtr(dest)
+		cc, cd := cInts(counts), cInts(displs)
+		rc, rd = &cc[0], &cd[0]
 	}
-	return Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.INT16, recvbuf, C.int(len(orig)), C.INT16, C.int(toProc), cm.comm), "GatherI16")
+	return Error(C.MPI_Gatherv(sendbuf, C.int(len(orig)), C.INT16, recvbuf, rc, rd, C.INT16, C.int(toProc), cm.comm), "GathervI16")
 }
 
 // AllGatherI16 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) AllGatherI16(dest, orig []int16) error {
-	sendbuf := unsafe.Pointer(&orig[0])
-	recvbuf := unsafe.Pointer(&dest[0])
+	cm.countMetric("AllGather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.INT16, recvbuf, C.int(len(orig)), C.INT16, cm.comm), "GatherI16")
 }
 
+// AllGathervI16 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervI16(dest, orig []int16, counts, displs []int) error {
+	cm.countMetric("AllGatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if displs == nil {
+		displs, _ = Displacements(counts)
+	}
+	// counts and displs are the same on all procs, so all procs return together.
+	if len(counts) != np || len(displs) != np {
+		return errorf("mpi.AllGathervI16: counts and displacements must have length equal to number of procs: %d", np)
+	}
+	if err := checkCounts("AllGathervI16", "dest", len(dest), counts, displs); err != nil {
+		return err
+	}
+	// but len(orig) is only known locally, so a mismatch is sent from
+	// scratch, to keep the other procs from blocking, and returned after.
+	rank := cm.Rank()
+	send := orig
+	var oerr error
+	if counts[rank] != len(orig) {
+		oerr = errorf("mpi.AllGathervI16: counts[%d] %d is not equal to len(orig): %d", rank, counts[rank], len(orig))
+		send = make([]int16, counts[rank])
+		copy(send, orig)
+	}
+	sendbuf := bufPtr(send)
+	recvbuf := bufPtr(dest)
+	cc, cd := cInts(counts), cInts(displs)
+	err := Error(C.MPI_Allgatherv(sendbuf, C.int(len(send)), C.INT16, recvbuf, &cc[0], &cd[0], C.INT16, cm.comm), "AllGathervI16")
+	if err != nil {
+		return err
+	}
+	return oerr
+}
+
+// AllGatherInPlaceI16 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceI16(buf []int16) error {
+	cm.countMetric("AllGatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(buf)%np != 0 {
+		return errorf("mpi.AllGatherInPlaceI16: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	n := len(buf) / np
+	recvbuf := bufPtr(buf)
+	return Error(C.MPI_Allgather(C.MPI_IN_PLACE, 0, C.INT16, recvbuf, C.int(n), C.INT16, cm.comm), "AllGatherInPlaceI16")
+}
+
 // ScatterI16 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
-// sendbuf is ignored on all procs except fmProc.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterI16(fmProc int, dest, orig []int16) error {
+	cm.countMetric("Scatter", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	isFrom := cm.Rank() == fmProc
+	var err error
+	if isFrom && len(orig) < cm.Size()*len(dest) {
+		err = errorf("mpi.ScatterI16: len(orig) %d < number of procs %d * len(dest) %d", len(orig), cm.Size(), len(dest))
+	}
+	if err = cm.rootCheck(fmProc, err, "ScatterI16"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
-	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+	if isFrom {
+		sendbuf = bufPtr(orig)
+	}
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.INT16, recvbuf, C.int(len(dest)), C.INT16, C.int(fmProc), cm.comm), "ScatterI16")
+}
+
+// ScattervI16 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervI16(fmProc int, dest, orig []int16, counts, displs []int) error {
+	cm.countMetric("Scatterv", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	rank := cm.Rank()
+	np := cm.Size()
+	isFrom := rank == fmProc
+	var err error
+	if isFrom {
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.ScattervI16: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[fmProc] > len(dest):
+			err = errorf("mpi.ScattervI16: len(dest) %d is less than counts[%d]: %d", len(dest), fmProc, counts[fmProc])
+		default:
+			err = checkCounts("ScattervI16", "orig", len(orig), counts, displs)
+		}
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.INT16, recvbuf, C.int(len(dest)), C.INT16, C.int(fmProc), cm.comm), "GatherI16")
+	if err = cm.rootCheck(fmProc, err, "ScattervI16"); err != nil {
+		return err
+	}
+	// a short dest on another proc cannot be reported before the collective,
+	// so that proc receives into scratch and returns the error after.
+	recv := dest
+	var derr error
+	if !isFrom && len(counts) == np && counts[rank] > len(dest) {
+		derr = errorf("mpi.ScattervI16: len(dest) %d is less than counts[%d]: %d", len(dest), rank, counts[rank])
+		recv = make([]int16, counts[rank])
+	}
+	var sendbuf unsafe.Pointer
+	recvbuf := bufPtr(recv)
+	var sc, sd *C.int
+	if isFrom {
+		sendbuf = bufPtr(orig)
+		cc, cd := cInts(counts), cInts(displs)
+		sc, sd = &cc[0], &cd[0]
+	}
+	err = Error(C.MPI_Scatterv(sendbuf, sc, sd, C.INT16, recvbuf, C.int(len(recv)), C.INT16, C.int(fmProc), cm.comm), "ScattervI16")
+	if err != nil {
+		return err
+	}
+	return derr
+}
+
+// AllToAllI16 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllI16(dest, orig []int16) error {
+	cm.countMetric("AllToAll", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(orig)%np != 0 || len(dest)%np != 0 {
+		return errorf("mpi.AllToAllI16: len(orig) %d and len(dest) %d must be evenly divisible by number of procs %d", len(orig), len(dest), np)
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllToAllI16: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	n := len(orig) / np
+	return Error(C.MPI_Alltoall(bufPtr(orig), C.int(n), C.INT16, bufPtr(dest), C.int(n), C.INT16, cm.comm), "AllToAllI16")
+}
+
+// AllToAllvI16 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvI16(dest, orig []int16, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	cm.countMetric("AllToAllv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if sendDispls == nil {
+		sendDispls, _ = Displacements(sendCounts)
+	}
+	if recvDispls == nil {
+		recvDispls, _ = Displacements(recvCounts)
+	}
+	// the counts differ across procs, so any bad ones are reported on all procs.
+	var err error
+	if len(sendCounts) != np || len(recvCounts) != np || len(sendDispls) != np || len(recvDispls) != np {
+		err = errorf("mpi.AllToAllvI16: counts and displacements must have length equal to number of procs: %d", np)
+	} else if err = checkCounts("AllToAllvI16", "orig", len(orig), sendCounts, sendDispls); err == nil {
+		err = checkCounts("AllToAllvI16", "dest", len(dest), recvCounts, recvDispls)
+	}
+	if err = cm.allCheck(err, "AllToAllvI16"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	sc, sd := cInts(sendCounts), cInts(sendDispls)
+	rc, rd := cInts(recvCounts), cInts(recvDispls)
+	return Error(C.MPI_Alltoallv(sendbuf, &sc[0], &sd[0], C.INT16, recvbuf, &rc[0], &rd[0], C.INT16, cm.comm), "AllToAllvI16")
 }
 
 // SendU16 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendU16(toProc int, tag int, vals []uint16) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Send", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Send(buf, C.int(len(vals)), C.UINT16, C.int(toProc), C.int(tag), cm.comm), "SendU16")
 }
 
-// Recv64U16 receives values from proc fmProc, using given unique tag identifier
+// RecvU16 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvU16(fmProc int, tag int, vals []uint16) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Recv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.UINT16, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvU16")
 }
 
+// IsendU16 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendU16(toProc int, tag int, vals []uint16) (*Request, error) {
+	cm.countMetric("Isend", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRequest(bufPtr(vals))
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Isend(buf, C.int(len(vals)), C.UINT16, C.int(toProc), C.int(tag), cm.comm, &r.req), "IsendU16")
+}
+
+// IrecvU16 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvU16(fmProc int, tag int, vals []uint16) (*Request, error) {
+	cm.countMetric("Irecv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRecvRequest(bufPtr(vals), C.UINT16)
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Irecv(buf, C.int(len(vals)), C.UINT16, C.int(fmProc), C.int(tag), cm.comm, &r.req), "IrecvU16")
+}
+
 // BcastU16 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastU16(fmProc int, vals []uint16) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Bcast", len(vals)*int(unsafe.Sizeof(vals[0])))
+	defer cm.enterCollective()()
+	buf := bufPtr(vals)
 	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.UINT16, C.int(fmProc), cm.comm), "BcastU16")
 }
 
 // ReduceU16 reduces all values across procs to toProc in orig to dest using given operation.
-// recvbuf is ignored on all procs except toProc.
+// If VerifyReduce is set, the result is checked against an AllReduce.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceU16(toProc int, op Op, dest, orig []uint16) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Reduce", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ReduceU16"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ReduceU16"); err != nil {
+		return err
+	}
+	isTo := cm.Rank() == toProc
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var derr error
+	if isTo {
+		if len(dest) < len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.ReduceU16: len(dest) %d < len(orig) %d", len(dest), len(orig))
+			recvbuf = bufPtr(make([]uint16, len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(orig)), C.UINT16, op.ToC(), C.int(toProc), cm.comm), "ReduceU16")
+	if err == nil && VerifyReduce {
+		ver := make([]uint16, len(orig))
+		err = Error(C.MPI_Allreduce(sendbuf, bufPtr(ver), C.int(len(orig)), C.UINT16, op.ToC(), cm.comm), "ReduceU16")
+		if err == nil && isTo && derr == nil {
+			err = verifyReduce(dest, ver, "ReduceU16")
+		}
+	}
+	if derr != nil {
+		return derr
 	}
-	return Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(dest)), C.UINT16, op.ToC(), C.int(toProc), cm.comm), "ReduceU16")
+	return err
 }
 
 // AllReduceU16 reduces all values across procs to all procs from orig into dest using given operation.
 // IMPORTANT: orig and dest must be different slices
 // To do an in-place operation, set orig to nil
 func (cm *Comm) AllReduceU16(op Op, dest, orig []uint16) error {
+	cm.countMetric("AllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceU16"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceU16"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
 	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+		sendbuf = bufPtr(orig)
 	} else {
 		sendbuf = C.MPI_IN_PLACE
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.UINT16, op.ToC(), cm.comm), "AllReduceU16")
 }
 
+// IAllReduceU16 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceU16(op Op, dest, orig []uint16) (*Request, error) {
+	cm.countMetric("IAllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "IAllReduceU16"); err != nil {
+		return nil, err
+	}
+	if err := cm.checkOp(op, "IAllReduceU16"); err != nil {
+		return nil, err
+	}
+	r := newRequest(bufPtr(dest))
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = bufPtr(orig)
+		if sendbuf != nil {
+			r.pin.Pin(sendbuf)
+		}
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := bufPtr(dest)
+	return r, Error(C.MPI_Iallreduce(sendbuf, recvbuf, C.int(len(dest)), C.UINT16, op.ToC(), cm.comm, &r.req), "IAllReduceU16")
+}
+
+// ScanU16 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanU16(op Op, dest, orig []uint16) error {
+	cm.countMetric("Scan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ScanU16"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ScanU16"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ScanU16: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	return Error(C.MPI_Scan(bufPtr(orig), bufPtr(dest), C.int(len(orig)), C.UINT16, op.ToC(), cm.comm), "ScanU16")
+}
+
+// ExScanU16 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanU16(op Op, dest, orig []uint16) error {
+	cm.countMetric("ExScan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ExScanU16"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ExScanU16"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ExScanU16: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	recvbuf := bufPtr(dest)
+	if cm.Rank() == 0 {
+		recvbuf = bufPtr(make([]uint16, len(orig)))
+	}
+	return Error(C.MPI_Exscan(bufPtr(orig), recvbuf, C.int(len(orig)), C.UINT16, op.ToC(), cm.comm), "ExScanU16")
+}
+
 // GatherU16 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
-// recvbuf is ignored on all procs except toProc.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherU16(toProc int, dest, orig []uint16) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Gather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	var recvbuf unsafe.Pointer
+	var derr error
+	if cm.Rank() == toProc {
+		np := cm.Size()
+		if len(dest) < np*len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.GatherU16: len(dest) %d < number of procs %d * len(orig) %d", len(dest), np, len(orig))
+			recvbuf = bufPtr(make([]uint16, np*len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.UINT16, recvbuf, C.int(len(orig)), C.UINT16, C.int(toProc), cm.comm), "GatherU16")
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+// GatherInPlaceU16 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceU16(toProc int, buf []uint16) error {
+	cm.countMetric("GatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	isTo := cm.Rank() == toProc
+	np := cm.Size()
+	var err error
+	if isTo && len(buf)%np != 0 {
+		err = errorf("mpi.GatherInPlaceU16: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	if err = cm.rootCheck(toProc, err, "GatherInPlaceU16"); err != nil {
+		return err
+	}
+	ptr := bufPtr(buf)
+	if !isTo {
+		return Error(C.MPI_Gather(ptr, C.int(len(buf)), C.UINT16, nil, 0, C.UINT16, C.int(toProc), cm.comm), "GatherInPlaceU16")
+	}
+	n := len(buf) / np
+	return Error(C.MPI_Gather(C.MPI_IN_PLACE, 0, C.UINT16, ptr, C.int(n), C.UINT16, C.int(toProc), cm.comm), "GatherInPlaceU16")
+}
+
+// GathervU16 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervU16(toProc int, dest, orig []uint16, counts, displs []int) error {
+	cm.countMetric("Gatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	isTo := cm.Rank() == toProc
+	var err error
+	if isTo {
+		np := cm.Size()
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.GathervU16: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[toProc] != len(orig):
+			err = errorf("mpi.GathervU16: counts[%d] %d is not equal to len(orig): %d", toProc, counts[toProc], len(orig))
+		default:
+			err = checkCounts("GathervU16", "dest", len(dest), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(toProc, err, "GathervU16"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var rc, rd *C.int
+	if isTo {
+		recvbuf = bufPtr(dest)
+		cc, cd := cInts(counts), cInts(displs)
+		rc, rd = &cc[0], &cd[0]
 	}
-	return Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.UINT16, recvbuf, C.int(len(orig)), C.UINT16, C.int(toProc), cm.comm), "GatherU16")
+	return Error(C.MPI_Gatherv(sendbuf, C.int(len(orig)), C.UINT16, recvbuf, rc, rd, C.UINT16, C.int(toProc), cm.comm), "GathervU16")
 }
 
 // AllGatherU16 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) AllGatherU16(dest, orig []uint16) error {
-	sendbuf := unsafe.Pointer(&orig[0])
-	recvbuf := unsafe.Pointer(&dest[0])
+	cm.countMetric("AllGather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.UINT16, recvbuf, C.int(len(orig)), C.UINT16, cm.comm), "GatherU16")
 }
 
+// AllGathervU16 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervU16(dest, orig []uint16, counts, displs []int) error {
+	cm.countMetric("AllGatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if displs == nil {
+		displs, _ = Displacements(counts)
+	}
+	// counts and displs are the same on all procs, so all procs return together.
+	if len(counts) != np || len(displs) != np {
+		return errorf("mpi.AllGathervU16: counts and displacements must have length equal to number of procs: %d", np)
+	}
+	if err := checkCounts("AllGathervU16", "dest", len(dest), counts, displs); err != nil {
+		return err
+	}
+	// but len(orig) is only known locally, so a mismatch is sent from
+	// scratch, to keep the other procs from blocking, and returned after.
+	rank := cm.Rank()
+	send := orig
+	var oerr error
+	if counts[rank] != len(orig) {
+		oerr = errorf("mpi.AllGathervU16: counts[%d] %d is not equal to len(orig): %d", rank, counts[rank], len(orig))
+		send = make([]uint16, counts[rank])
+		copy(send, orig)
+	}
+	sendbuf := bufPtr(send)
+	recvbuf := bufPtr(dest)
+	cc, cd := cInts(counts), cInts(displs)
+	err := Error(C.MPI_Allgatherv(sendbuf, C.int(len(send)), C.UINT16, recvbuf, &cc[0], &cd[0], C.UINT16, cm.comm), "AllGathervU16")
+	if err != nil {
+		return err
+	}
+	return oerr
+}
+
+// AllGatherInPlaceU16 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceU16(buf []uint16) error {
+	cm.countMetric("AllGatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(buf)%np != 0 {
+		return errorf("mpi.AllGatherInPlaceU16: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	n := len(buf) / np
+	recvbuf := bufPtr(buf)
+	return Error(C.MPI_Allgather(C.MPI_IN_PLACE, 0, C.UINT16, recvbuf, C.int(n), C.UINT16, cm.comm), "AllGatherInPlaceU16")
+}
+
 // ScatterU16 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
-// sendbuf is ignored on all procs except fmProc.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterU16(fmProc int, dest, orig []uint16) error {
+	cm.countMetric("Scatter", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	isFrom := cm.Rank() == fmProc
+	var err error
+	if isFrom && len(orig) < cm.Size()*len(dest) {
+		err = errorf("mpi.ScatterU16: len(orig) %d < number of procs %d * len(dest) %d", len(orig), cm.Size(), len(dest))
+	}
+	if err = cm.rootCheck(fmProc, err, "ScatterU16"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
-	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+	if isFrom {
+		sendbuf = bufPtr(orig)
+	}
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.UINT16, recvbuf, C.int(len(dest)), C.UINT16, C.int(fmProc), cm.comm), "ScatterU16")
+}
+
+// ScattervU16 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervU16(fmProc int, dest, orig []uint16, counts, displs []int) error {
+	cm.countMetric("Scatterv", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	rank := cm.Rank()
+	np := cm.Size()
+	isFrom := rank == fmProc
+	var err error
+	if isFrom {
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.ScattervU16: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[fmProc] > len(dest):
+			err = errorf("mpi.ScattervU16: len(dest) %d is less than counts[%d]: %d", len(dest), fmProc, counts[fmProc])
+		default:
+			err = checkCounts("ScattervU16", "orig", len(orig), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(fmProc, err, "ScattervU16"); err != nil {
+		return err
+	}
+	// a short dest on another proc cannot be reported before the collective,
+	// so that proc receives into scratch and returns the error after.
+	recv := dest
+	var derr error
+	if !isFrom && len(counts) == np && counts[rank] > len(dest) {
+		derr = errorf("mpi.ScattervU16: len(dest) %d is less than counts[%d]: %d", len(dest), rank, counts[rank])
+		recv = make([]uint16, counts[rank])
+	}
+	var sendbuf unsafe.Pointer
+	recvbuf := bufPtr(recv)
+	var sc, sd *C.int
+	if isFrom {
+		sendbuf = bufPtr(orig)
+		cc, cd := cInts(counts), cInts(displs)
+		sc, sd = &cc[0], &cd[0]
+	}
+	err = Error(C.MPI_Scatterv(sendbuf, sc, sd, C.UINT16, recvbuf, C.int(len(recv)), C.UINT16, C.int(fmProc), cm.comm), "ScattervU16")
+	if err != nil {
+		return err
+	}
+	return derr
+}
+
+// AllToAllU16 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllU16(dest, orig []uint16) error {
+	cm.countMetric("AllToAll", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(orig)%np != 0 || len(dest)%np != 0 {
+		return errorf("mpi.AllToAllU16: len(orig) %d and len(dest) %d must be evenly divisible by number of procs %d", len(orig), len(dest), np)
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllToAllU16: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	n := len(orig) / np
+	return Error(C.MPI_Alltoall(bufPtr(orig), C.int(n), C.UINT16, bufPtr(dest), C.int(n), C.UINT16, cm.comm), "AllToAllU16")
+}
+
+// AllToAllvU16 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvU16(dest, orig []uint16, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	cm.countMetric("AllToAllv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if sendDispls == nil {
+		sendDispls, _ = Displacements(sendCounts)
+	}
+	if recvDispls == nil {
+		recvDispls, _ = Displacements(recvCounts)
+	}
+	// the counts differ across procs, so any bad ones are reported on all procs.
+	var err error
+	if len(sendCounts) != np || len(recvCounts) != np || len(sendDispls) != np || len(recvDispls) != np {
+		err = errorf("mpi.AllToAllvU16: counts and displacements must have length equal to number of procs: %d", np)
+	} else if err = checkCounts("AllToAllvU16", "orig", len(orig), sendCounts, sendDispls); err == nil {
+		err = checkCounts("AllToAllvU16", "dest", len(dest), recvCounts, recvDispls)
+	}
+	if err = cm.allCheck(err, "AllToAllvU16"); err != nil {
+		return err
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.UINT16, recvbuf, C.int(len(dest)), C.UINT16, C.int(fmProc), cm.comm), "GatherU16")
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	sc, sd := cInts(sendCounts), cInts(sendDispls)
+	rc, rd := cInts(recvCounts), cInts(recvDispls)
+	return Error(C.MPI_Alltoallv(sendbuf, &sc[0], &sd[0], C.UINT16, recvbuf, &rc[0], &rd[0], C.UINT16, cm.comm), "AllToAllvU16")
 }
 
 // SendI8 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendI8(toProc int, tag int, vals []int8) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Send", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Send(buf, C.int(len(vals)), C.BYTE, C.int(toProc), C.int(tag), cm.comm), "SendI8")
 }
 
-// Recv64I8 receives values from proc fmProc, using given unique tag identifier
+// RecvI8 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvI8(fmProc int, tag int, vals []int8) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Recv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.BYTE, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvI8")
 }
 
+// IsendI8 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendI8(toProc int, tag int, vals []int8) (*Request, error) {
+	cm.countMetric("Isend", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRequest(bufPtr(vals))
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Isend(buf, C.int(len(vals)), C.BYTE, C.int(toProc), C.int(tag), cm.comm, &r.req), "IsendI8")
+}
+
+// IrecvI8 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvI8(fmProc int, tag int, vals []int8) (*Request, error) {
+	cm.countMetric("Irecv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRecvRequest(bufPtr(vals), C.BYTE)
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Irecv(buf, C.int(len(vals)), C.BYTE, C.int(fmProc), C.int(tag), cm.comm, &r.req), "IrecvI8")
+}
+
 // BcastI8 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastI8(fmProc int, vals []int8) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Bcast", len(vals)*int(unsafe.Sizeof(vals[0])))
+	defer cm.enterCollective()()
+	buf := bufPtr(vals)
 	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.BYTE, C.int(fmProc), cm.comm), "BcastI8")
 }
 
 // ReduceI8 reduces all values across procs to toProc in orig to dest using given operation.
-// recvbuf is ignored on all procs except toProc.
+// If VerifyReduce is set, the result is checked against an AllReduce.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceI8(toProc int, op Op, dest, orig []int8) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Reduce", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ReduceI8"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ReduceI8"); err != nil {
+		return err
+	}
+	isTo := cm.Rank() == toProc
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var derr error
+	if isTo {
+		if len(dest) < len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.ReduceI8: len(dest) %d < len(orig) %d", len(dest), len(orig))
+			recvbuf = bufPtr(make([]int8, len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(orig)), C.BYTE, op.ToC(), C.int(toProc), cm.comm), "ReduceI8")
+	if err == nil && VerifyReduce {
+		ver := make([]int8, len(orig))
+		err = Error(C.MPI_Allreduce(sendbuf, bufPtr(ver), C.int(len(orig)), C.BYTE, op.ToC(), cm.comm), "ReduceI8")
+		if err == nil && isTo && derr == nil {
+			err = verifyReduce(dest, ver, "ReduceI8")
+		}
+	}
+	if derr != nil {
+		return derr
 	}
-	return Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(dest)), C.BYTE, op.ToC(), C.int(toProc), cm.comm), "ReduceI8")
+	return err
 }
 
 // AllReduceI8 reduces all values across procs to all procs from orig into dest using given operation.
 // IMPORTANT: orig and dest must be different slices
 // To do an in-place operation, set orig to nil
 func (cm *Comm) AllReduceI8(op Op, dest, orig []int8) error {
+	cm.countMetric("AllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceI8"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceI8"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
 	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+		sendbuf = bufPtr(orig)
 	} else {
 		sendbuf = C.MPI_IN_PLACE
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.BYTE, op.ToC(), cm.comm), "AllReduceI8")
 }
 
+// IAllReduceI8 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceI8(op Op, dest, orig []int8) (*Request, error) {
+	cm.countMetric("IAllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "IAllReduceI8"); err != nil {
+		return nil, err
+	}
+	if err := cm.checkOp(op, "IAllReduceI8"); err != nil {
+		return nil, err
+	}
+	r := newRequest(bufPtr(dest))
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = bufPtr(orig)
+		if sendbuf != nil {
+			r.pin.Pin(sendbuf)
+		}
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := bufPtr(dest)
+	return r, Error(C.MPI_Iallreduce(sendbuf, recvbuf, C.int(len(dest)), C.BYTE, op.ToC(), cm.comm, &r.req), "IAllReduceI8")
+}
+
+// ScanI8 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanI8(op Op, dest, orig []int8) error {
+	cm.countMetric("Scan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ScanI8"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ScanI8"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ScanI8: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	return Error(C.MPI_Scan(bufPtr(orig), bufPtr(dest), C.int(len(orig)), C.BYTE, op.ToC(), cm.comm), "ScanI8")
+}
+
+// ExScanI8 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanI8(op Op, dest, orig []int8) error {
+	cm.countMetric("ExScan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ExScanI8"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ExScanI8"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ExScanI8: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	recvbuf := bufPtr(dest)
+	if cm.Rank() == 0 {
+		recvbuf = bufPtr(make([]int8, len(orig)))
+	}
+	return Error(C.MPI_Exscan(bufPtr(orig), recvbuf, C.int(len(orig)), C.BYTE, op.ToC(), cm.comm), "ExScanI8")
+}
+
 // GatherI8 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
-// recvbuf is ignored on all procs except toProc.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherI8(toProc int, dest, orig []int8) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Gather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	var recvbuf unsafe.Pointer
+	var derr error
+	if cm.Rank() == toProc {
+		np := cm.Size()
+		if len(dest) < np*len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.GatherI8: len(dest) %d < number of procs %d * len(orig) %d", len(dest), np, len(orig))
+			recvbuf = bufPtr(make([]int8, np*len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.BYTE, recvbuf, C.int(len(orig)), C.BYTE, C.int(toProc), cm.comm), "GatherI8")
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+// GatherInPlaceI8 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceI8(toProc int, buf []int8) error {
+	cm.countMetric("GatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	isTo := cm.Rank() == toProc
+	np := cm.Size()
+	var err error
+	if isTo && len(buf)%np != 0 {
+		err = errorf("mpi.GatherInPlaceI8: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	if err = cm.rootCheck(toProc, err, "GatherInPlaceI8"); err != nil {
+		return err
+	}
+	ptr := bufPtr(buf)
+	if !isTo {
+		return Error(C.MPI_Gather(ptr, C.int(len(buf)), C.BYTE, nil, 0, C.BYTE, C.int(toProc), cm.comm), "GatherInPlaceI8")
+	}
+	n := len(buf) / np
+	return Error(C.MPI_Gather(C.MPI_IN_PLACE, 0, C.BYTE, ptr, C.int(n), C.BYTE, C.int(toProc), cm.comm), "GatherInPlaceI8")
+}
+
+// GathervI8 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervI8(toProc int, dest, orig []int8, counts, displs []int) error {
+	cm.countMetric("Gatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	isTo := cm.Rank() == toProc
+	var err error
+	if isTo {
+		np := cm.Size()
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.GathervI8: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[toProc] != len(orig):
+			err = errorf("mpi.GathervI8: counts[%d] %d is not equal to len(orig): %d", toProc, counts[toProc], len(orig))
+		default:
+			err = checkCounts("GathervI8", "dest", len(dest), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(toProc, err, "GathervI8"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var rc, rd *C.int
+	if isTo {
+		recvbuf = bufPtr(dest)
+		cc, cd := cInts(counts), cInts(displs)
+		rc, rd = &cc[0], &cd[0]
 	}
-	return Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.BYTE, recvbuf, C.int(len(orig)), C.BYTE, C.int(toProc), cm.comm), "GatherI8")
+	return Error(C.MPI_Gatherv(sendbuf, C.int(len(orig)), C.BYTE, recvbuf, rc, rd, C.BYTE, C.int(toProc), cm.comm), "GathervI8")
 }
 
 // AllGatherI8 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) AllGatherI8(dest, orig []int8) error {
-	sendbuf := unsafe.Pointer(&orig[0])
-	recvbuf := unsafe.Pointer(&dest[0])
+	cm.countMetric("AllGather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.BYTE, recvbuf, C.int(len(orig)), C.BYTE, cm.comm), "GatherI8")
 }
 
+// AllGathervI8 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervI8(dest, orig []int8, counts, displs []int) error {
+	cm.countMetric("AllGatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if displs == nil {
+		displs, _ = Displacements(counts)
+	}
+	// counts and displs are the same on all procs, so all procs return together.
+	if len(counts) != np || len(displs) != np {
+		return errorf("mpi.AllGathervI8: counts and displacements must have length equal to number of procs: %d", np)
+	}
+	if err := checkCounts("AllGathervI8", "dest", len(dest), counts, displs); err != nil {
+		return err
+	}
+	// but len(orig) is only known locally, so a mismatch is sent from
+	// scratch, to keep the other procs from blocking, and returned after.
+	rank := cm.Rank()
+	send := orig
+	var oerr error
+	if counts[rank] != len(orig) {
+		oerr = errorf("mpi.AllGathervI8: counts[%d] %d is not equal to len(orig): %d", rank, counts[rank], len(orig))
+		send = make([]int8, counts[rank])
+		copy(send, orig)
+	}
+	sendbuf := bufPtr(send)
+	recvbuf := bufPtr(dest)
+	cc, cd := cInts(counts), cInts(displs)
+	err := Error(C.MPI_Allgatherv(sendbuf, C.int(len(send)), C.BYTE, recvbuf, &cc[0], &cd[0], C.BYTE, cm.comm), "AllGathervI8")
+	if err != nil {
+		return err
+	}
+	return oerr
+}
+
+// AllGatherInPlaceI8 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceI8(buf []int8) error {
+	cm.countMetric("AllGatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(buf)%np != 0 {
+		return errorf("mpi.AllGatherInPlaceI8: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	n := len(buf) / np
+	recvbuf := bufPtr(buf)
+	return Error(C.MPI_Allgather(C.MPI_IN_PLACE, 0, C.BYTE, recvbuf, C.int(n), C.BYTE, cm.comm), "AllGatherInPlaceI8")
+}
+
 // ScatterI8 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
-// sendbuf is ignored on all procs except fmProc.
+// orig is ignored, and can be nil, on all procs except fmProc.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScatterI8(fmProc int, dest, orig []int8) error {
+	cm.countMetric("Scatter", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	isFrom := cm.Rank() == fmProc
+	var err error
+	if isFrom && len(orig) < cm.Size()*len(dest) {
+		err = errorf("mpi.ScatterI8: len(orig) %d < number of procs %d * len(dest) %d", len(orig), cm.Size(), len(dest))
+	}
+	if err = cm.rootCheck(fmProc, err, "ScatterI8"); err != nil {
+		return err
+	}
+	var sendbuf unsafe.Pointer
+	if isFrom {
+		sendbuf = bufPtr(orig)
+	}
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.BYTE, recvbuf, C.int(len(dest)), C.BYTE, C.int(fmProc), cm.comm), "ScatterI8")
+}
+
+// ScattervI8 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervI8(fmProc int, dest, orig []int8, counts, displs []int) error {
+	cm.countMetric("Scatterv", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	rank := cm.Rank()
+	np := cm.Size()
+	isFrom := rank == fmProc
+	var err error
+	if isFrom {
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.ScattervI8: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[fmProc] > len(dest):
+			err = errorf("mpi.ScattervI8: len(dest) %d is less than counts[%d]: %d", len(dest), fmProc, counts[fmProc])
+		default:
+			err = checkCounts("ScattervI8", "orig", len(orig), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(fmProc, err, "ScattervI8"); err != nil {
+		return err
+	}
+	// a short dest on another proc cannot be reported before the collective,
+	// so that proc receives into scratch and returns the error after.
+	recv := dest
+	var derr error
+	if !isFrom && len(counts) == np && counts[rank] > len(dest) {
+		derr = errorf("mpi.ScattervI8: len(dest) %d is less than counts[%d]: %d", len(dest), rank, counts[rank])
+		recv = make([]int8, counts[rank])
+	}
+	var sendbuf unsafe.Pointer
+	recvbuf := bufPtr(recv)
+	var sc, sd *C.int
+	if isFrom {
+		sendbuf = bufPtr(orig)
+		cc, cd := cInts(counts), cInts(displs)
+		sc, sd = &cc[0], &cd[0]
+	}
+	err = Error(C.MPI_Scatterv(sendbuf, sc, sd, C.BYTE, recvbuf, C.int(len(recv)), C.BYTE, C.int(fmProc), cm.comm), "ScattervI8")
+	if err != nil {
+		return err
+	}
+	return derr
+}
+
+// AllToAllI8 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllI8(dest, orig []int8) error {
+	cm.countMetric("AllToAll", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(orig)%np != 0 || len(dest)%np != 0 {
+		return errorf("mpi.AllToAllI8: len(orig) %d and len(dest) %d must be evenly divisible by number of procs %d", len(orig), len(dest), np)
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllToAllI8: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	n := len(orig) / np
+	return Error(C.MPI_Alltoall(bufPtr(orig), C.int(n), C.BYTE, bufPtr(dest), C.int(n), C.BYTE, cm.comm), "AllToAllI8")
+}
+
+// AllToAllvI8 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
 // IMPORTANT: orig and dest must be different slices
-func (cm *Comm) ScatterI8(fmProc int, dest, orig []int8) error {
-	var sendbuf unsafe.Pointer
-	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+func (cm *Comm) AllToAllvI8(dest, orig []int8, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	cm.countMetric("AllToAllv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if sendDispls == nil {
+		sendDispls, _ = Displacements(sendCounts)
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.BYTE, recvbuf, C.int(len(dest)), C.BYTE, C.int(fmProc), cm.comm), "GatherI8")
+	if recvDispls == nil {
+		recvDispls, _ = Displacements(recvCounts)
+	}
+	// the counts differ across procs, so any bad ones are reported on all procs.
+	var err error
+	if len(sendCounts) != np || len(recvCounts) != np || len(sendDispls) != np || len(recvDispls) != np {
+		err = errorf("mpi.AllToAllvI8: counts and displacements must have length equal to number of procs: %d", np)
+	} else if err = checkCounts("AllToAllvI8", "orig", len(orig), sendCounts, sendDispls); err == nil {
+		err = checkCounts("AllToAllvI8", "dest", len(dest), recvCounts, recvDispls)
+	}
+	if err = cm.allCheck(err, "AllToAllvI8"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	sc, sd := cInts(sendCounts), cInts(sendDispls)
+	rc, rd := cInts(recvCounts), cInts(recvDispls)
+	return Error(C.MPI_Alltoallv(sendbuf, &sc[0], &sd[0], C.BYTE, recvbuf, &rc[0], &rd[0], C.BYTE, cm.comm), "AllToAllvI8")
 }
 
 // SendU8 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendU8(toProc int, tag int, vals []uint8) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Send", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Send(buf, C.int(len(vals)), C.BYTE, C.int(toProc), C.int(tag), cm.comm), "SendU8")
 }
 
-// Recv64U8 receives values from proc fmProc, using given unique tag identifier
+// RecvU8 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvU8(fmProc int, tag int, vals []uint8) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Recv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.BYTE, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvU8")
 }
 
+// IsendU8 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendU8(toProc int, tag int, vals []uint8) (*Request, error) {
+	cm.countMetric("Isend", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRequest(bufPtr(vals))
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Isend(buf, C.int(len(vals)), C.BYTE, C.int(toProc), C.int(tag), cm.comm, &r.req), "IsendU8")
+}
+
+// IrecvU8 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvU8(fmProc int, tag int, vals []uint8) (*Request, error) {
+	cm.countMetric("Irecv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRecvRequest(bufPtr(vals), C.BYTE)
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Irecv(buf, C.int(len(vals)), C.BYTE, C.int(fmProc), C.int(tag), cm.comm, &r.req), "IrecvU8")
+}
+
 // BcastU8 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastU8(fmProc int, vals []uint8) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Bcast", len(vals)*int(unsafe.Sizeof(vals[0])))
+	defer cm.enterCollective()()
+	buf := bufPtr(vals)
 	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.BYTE, C.int(fmProc), cm.comm), "BcastU8")
 }
 
 // ReduceU8 reduces all values across procs to toProc in orig to dest using given operation.
-// recvbuf is ignored on all procs except toProc.
+// If VerifyReduce is set, the result is checked against an AllReduce.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceU8(toProc int, op Op, dest, orig []uint8) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Reduce", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ReduceU8"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ReduceU8"); err != nil {
+		return err
+	}
+	isTo := cm.Rank() == toProc
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var derr error
+	if isTo {
+		if len(dest) < len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.ReduceU8: len(dest) %d < len(orig) %d", len(dest), len(orig))
+			recvbuf = bufPtr(make([]uint8, len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(orig)), C.BYTE, op.ToC(), C.int(toProc), cm.comm), "ReduceU8")
+	if err == nil && VerifyReduce {
+		ver := make([]uint8, len(orig))
+		err = Error(C.MPI_Allreduce(sendbuf, bufPtr(ver), C.int(len(orig)), C.BYTE, op.ToC(), cm.comm), "ReduceU8")
+		if err == nil && isTo && derr == nil {
+			err = verifyReduce(dest, ver, "ReduceU8")
+		}
 	}
-	return Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(dest)), C.BYTE, op.ToC(), C.int(toProc), cm.comm), "ReduceU8")
+	if derr != nil {
+		return derr
+	}
+	return err
 }
 
 // AllReduceU8 reduces all values across procs to all procs from orig into dest using given operation.
 // IMPORTANT: orig and dest must be different slices
 // To do an in-place operation, set orig to nil
 func (cm *Comm) AllReduceU8(op Op, dest, orig []uint8) error {
+	cm.countMetric("AllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceU8"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceU8"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
 	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+		sendbuf = bufPtr(orig)
 	} else {
 		sendbuf = C.MPI_IN_PLACE
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.BYTE, op.ToC(), cm.comm), "AllReduceU8")
 }
 
+// IAllReduceU8 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceU8(op Op, dest, orig []uint8) (*Request, error) {
+	cm.countMetric("IAllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "IAllReduceU8"); err != nil {
+		return nil, err
+	}
+	if err := cm.checkOp(op, "IAllReduceU8"); err != nil {
+		return nil, err
+	}
+	r := newRequest(bufPtr(dest))
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = bufPtr(orig)
+		if sendbuf != nil {
+			r.pin.Pin(sendbuf)
+		}
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := bufPtr(dest)
+	return r, Error(C.MPI_Iallreduce(sendbuf, recvbuf, C.int(len(dest)), C.BYTE, op.ToC(), cm.comm, &r.req), "IAllReduceU8")
+}
+
+// ScanU8 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanU8(op Op, dest, orig []uint8) error {
+	cm.countMetric("Scan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ScanU8"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ScanU8"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ScanU8: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	return Error(C.MPI_Scan(bufPtr(orig), bufPtr(dest), C.int(len(orig)), C.BYTE, op.ToC(), cm.comm), "ScanU8")
+}
+
+// ExScanU8 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanU8(op Op, dest, orig []uint8) error {
+	cm.countMetric("ExScan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ExScanU8"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ExScanU8"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ExScanU8: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	recvbuf := bufPtr(dest)
+	if cm.Rank() == 0 {
+		recvbuf = bufPtr(make([]uint8, len(orig)))
+	}
+	return Error(C.MPI_Exscan(bufPtr(orig), recvbuf, C.int(len(orig)), C.BYTE, op.ToC(), cm.comm), "ExScanU8")
+}
+
 // GatherU8 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
-// recvbuf is ignored on all procs except toProc.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherU8(toProc int, dest, orig []uint8) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Gather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	var recvbuf unsafe.Pointer
+	var derr error
+	if cm.Rank() == toProc {
+		np := cm.Size()
+		if len(dest) < np*len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.GatherU8: len(dest) %d < number of procs %d * len(orig) %d", len(dest), np, len(orig))
+			recvbuf = bufPtr(make([]uint8, np*len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.BYTE, recvbuf, C.int(len(orig)), C.BYTE, C.int(toProc), cm.comm), "GatherU8")
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+// GatherInPlaceU8 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceU8(toProc int, buf []uint8) error {
+	cm.countMetric("GatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	isTo := cm.Rank() == toProc
+	np := cm.Size()
+	var err error
+	if isTo && len(buf)%np != 0 {
+		err = errorf("mpi.GatherInPlaceU8: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	if err = cm.rootCheck(toProc, err, "GatherInPlaceU8"); err != nil {
+		return err
+	}
+	ptr := bufPtr(buf)
+	if !isTo {
+		return Error(C.MPI_Gather(ptr, C.int(len(buf)), C.BYTE, nil, 0, C.BYTE, C.int(toProc), cm.comm), "GatherInPlaceU8")
+	}
+	n := len(buf) / np
+	return Error(C.MPI_Gather(C.MPI_IN_PLACE, 0, C.BYTE, ptr, C.int(n), C.BYTE, C.int(toProc), cm.comm), "GatherInPlaceU8")
+}
+
+// GathervU8 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervU8(toProc int, dest, orig []uint8, counts, displs []int) error {
+	cm.countMetric("Gatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	isTo := cm.Rank() == toProc
+	var err error
+	if isTo {
+		np := cm.Size()
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.GathervU8: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[toProc] != len(orig):
+			err = errorf("mpi.GathervU8: counts[%d] %d is not equal to len(orig): %d", toProc, counts[toProc], len(orig))
+		default:
+			err = checkCounts("GathervU8", "dest", len(dest), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(toProc, err, "GathervU8"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var rc, rd *C.int
+	if isTo {
+		recvbuf = bufPtr(dest)
+		cc, cd := cInts(counts), cInts(displs)
+		rc, rd = &cc[0], &cd[0]
 	}
-	return Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.BYTE, recvbuf, C.int(len(orig)), C.BYTE, C.int(toProc), cm.comm), "GatherU8")
+	return Error(C.MPI_Gatherv(sendbuf, C.int(len(orig)), C.BYTE, recvbuf, rc, rd, C.BYTE, C.int(toProc), cm.comm), "GathervU8")
 }
 
 // AllGatherU8 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) AllGatherU8(dest, orig []uint8) error {
-	sendbuf := unsafe.Pointer(&orig[0])
-	recvbuf := unsafe.Pointer(&dest[0])
+	cm.countMetric("AllGather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.BYTE, recvbuf, C.int(len(orig)), C.BYTE, cm.comm), "GatherU8")
 }
 
+// AllGathervU8 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervU8(dest, orig []uint8, counts, displs []int) error {
+	cm.countMetric("AllGatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if displs == nil {
+		displs, _ = Displacements(counts)
+	}
+	// counts and displs are the same on all procs, so all procs return together.
+	if len(counts) != np || len(displs) != np {
+		return errorf("mpi.AllGathervU8: counts and displacements must have length equal to number of procs: %d", np)
+	}
+	if err := checkCounts("AllGathervU8", "dest", len(dest), counts, displs); err != nil {
+		return err
+	}
+	// but len(orig) is only known locally, so a mismatch is sent from
+	// scratch, to keep the other procs from blocking, and returned after.
+	rank := cm.Rank()
+	send := orig
+	var oerr error
+	if counts[rank] != len(orig) {
+		oerr = errorf("mpi.AllGathervU8: counts[%d] %d is not equal to len(orig): %d", rank, counts[rank], len(orig))
+		send = make([]uint8, counts[rank])
+		copy(send, orig)
+	}
+	sendbuf := bufPtr(send)
+	recvbuf := bufPtr(dest)
+	cc, cd := cInts(counts), cInts(displs)
+	err := Error(C.MPI_Allgatherv(sendbuf, C.int(len(send)), C.BYTE, recvbuf, &cc[0], &cd[0], C.BYTE, cm.comm), "AllGathervU8")
+	if err != nil {
+		return err
+	}
+	return oerr
+}
+
+// AllGatherInPlaceU8 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceU8(buf []uint8) error {
+	cm.countMetric("AllGatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(buf)%np != 0 {
+		return errorf("mpi.AllGatherInPlaceU8: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	n := len(buf) / np
+	recvbuf := bufPtr(buf)
+	return Error(C.MPI_Allgather(C.MPI_IN_PLACE, 0, C.BYTE, recvbuf, C.int(n), C.BYTE, cm.comm), "AllGatherInPlaceU8")
+}
+
 // ScatterU8 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
-// sendbuf is ignored on all procs except fmProc.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterU8(fmProc int, dest, orig []uint8) error {
+	cm.countMetric("Scatter", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	isFrom := cm.Rank() == fmProc
+	var err error
+	if isFrom && len(orig) < cm.Size()*len(dest) {
+		err = errorf("mpi.ScatterU8: len(orig) %d < number of procs %d * len(dest) %d", len(orig), cm.Size(), len(dest))
+	}
+	if err = cm.rootCheck(fmProc, err, "ScatterU8"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
-	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+	if isFrom {
+		sendbuf = bufPtr(orig)
+	}
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.BYTE, recvbuf, C.int(len(dest)), C.BYTE, C.int(fmProc), cm.comm), "ScatterU8")
+}
+
+// ScattervU8 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervU8(fmProc int, dest, orig []uint8, counts, displs []int) error {
+	cm.countMetric("Scatterv", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	rank := cm.Rank()
+	np := cm.Size()
+	isFrom := rank == fmProc
+	var err error
+	if isFrom {
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.ScattervU8: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[fmProc] > len(dest):
+			err = errorf("mpi.ScattervU8: len(dest) %d is less than counts[%d]: %d", len(dest), fmProc, counts[fmProc])
+		default:
+			err = checkCounts("ScattervU8", "orig", len(orig), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(fmProc, err, "ScattervU8"); err != nil {
+		return err
+	}
+	// a short dest on another proc cannot be reported before the collective,
+	// so that proc receives into scratch and returns the error after.
+	recv := dest
+	var derr error
+	if !isFrom && len(counts) == np && counts[rank] > len(dest) {
+		derr = errorf("mpi.ScattervU8: len(dest) %d is less than counts[%d]: %d", len(dest), rank, counts[rank])
+		recv = make([]uint8, counts[rank])
+	}
+	var sendbuf unsafe.Pointer
+	recvbuf := bufPtr(recv)
+	var sc, sd *C.int
+	if isFrom {
+		sendbuf = bufPtr(orig)
+		cc, cd := cInts(counts), cInts(displs)
+		sc, sd = &cc[0], &cd[0]
+	}
+	err = Error(C.MPI_Scatterv(sendbuf, sc, sd, C.BYTE, recvbuf, C.int(len(recv)), C.BYTE, C.int(fmProc), cm.comm), "ScattervU8")
+	if err != nil {
+		return err
+	}
+	return derr
+}
+
+// AllToAllU8 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllU8(dest, orig []uint8) error {
+	cm.countMetric("AllToAll", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(orig)%np != 0 || len(dest)%np != 0 {
+		return errorf("mpi.AllToAllU8: len(orig) %d and len(dest) %d must be evenly divisible by number of procs %d", len(orig), len(dest), np)
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllToAllU8: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	n := len(orig) / np
+	return Error(C.MPI_Alltoall(bufPtr(orig), C.int(n), C.BYTE, bufPtr(dest), C.int(n), C.BYTE, cm.comm), "AllToAllU8")
+}
+
+// AllToAllvU8 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvU8(dest, orig []uint8, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	cm.countMetric("AllToAllv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if sendDispls == nil {
+		sendDispls, _ = Displacements(sendCounts)
+	}
+	if recvDispls == nil {
+		recvDispls, _ = Displacements(recvCounts)
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.BYTE, recvbuf, C.int(len(dest)), C.BYTE, C.int(fmProc), cm.comm), "GatherU8")
+	// the counts differ across procs, so any bad ones are reported on all procs.
+	var err error
+	if len(sendCounts) != np || len(recvCounts) != np || len(sendDispls) != np || len(recvDispls) != np {
+		err = errorf("mpi.AllToAllvU8: counts and displacements must have length equal to number of procs: %d", np)
+	} else if err = checkCounts("AllToAllvU8", "orig", len(orig), sendCounts, sendDispls); err == nil {
+		err = checkCounts("AllToAllvU8", "dest", len(dest), recvCounts, recvDispls)
+	}
+	if err = cm.allCheck(err, "AllToAllvU8"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	sc, sd := cInts(sendCounts), cInts(sendDispls)
+	rc, rd := cInts(recvCounts), cInts(recvDispls)
+	return Error(C.MPI_Alltoallv(sendbuf, &sc[0], &sd[0], C.BYTE, recvbuf, &rc[0], &rd[0], C.BYTE, cm.comm), "AllToAllvU8")
 }
 
 // SendC128 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendC128(toProc int, tag int, vals []complex128) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Send", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Send(buf, C.int(len(vals)), C.COMPLEX128, C.int(toProc), C.int(tag), cm.comm), "SendC128")
 }
 
-// Recv64C128 receives values from proc fmProc, using given unique tag identifier
+// RecvC128 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvC128(fmProc int, tag int, vals []complex128) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Recv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.COMPLEX128, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvC128")
 }
 
+// IsendC128 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendC128(toProc int, tag int, vals []complex128) (*Request, error) {
+	cm.countMetric("Isend", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRequest(bufPtr(vals))
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Isend(buf, C.int(len(vals)), C.COMPLEX128, C.int(toProc), C.int(tag), cm.comm, &r.req), "IsendC128")
+}
+
+// IrecvC128 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvC128(fmProc int, tag int, vals []complex128) (*Request, error) {
+	cm.countMetric("Irecv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRecvRequest(bufPtr(vals), C.COMPLEX128)
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Irecv(buf, C.int(len(vals)), C.COMPLEX128, C.int(fmProc), C.int(tag), cm.comm, &r.req), "IrecvC128")
+}
+
 // BcastC128 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastC128(fmProc int, vals []complex128) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Bcast", len(vals)*int(unsafe.Sizeof(vals[0])))
+	defer cm.enterCollective()()
+	buf := bufPtr(vals)
 	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.COMPLEX128, C.int(fmProc), cm.comm), "BcastC128")
 }
 
 // ReduceC128 reduces all values across procs to toProc in orig to dest using given operation.
-// recvbuf is ignored on all procs except toProc.
+// If VerifyReduce is set, the result is checked against an AllReduce.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceC128(toProc int, op Op, dest, orig []complex128) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Reduce", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ReduceC128"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ReduceC128"); err != nil {
+		return err
+	}
+	isTo := cm.Rank() == toProc
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var derr error
+	if isTo {
+		if len(dest) < len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.ReduceC128: len(dest) %d < len(orig) %d", len(dest), len(orig))
+			recvbuf = bufPtr(make([]complex128, len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
 	}
-	return Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(dest)), C.COMPLEX128, op.ToC(), C.int(toProc), cm.comm), "ReduceC128")
+	err := Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(orig)), C.COMPLEX128, op.ToC(), C.int(toProc), cm.comm), "ReduceC128")
+	if err == nil && VerifyReduce {
+		ver := make([]complex128, len(orig))
+		err = Error(C.MPI_Allreduce(sendbuf, bufPtr(ver), C.int(len(orig)), C.COMPLEX128, op.ToC(), cm.comm), "ReduceC128")
+		if err == nil && isTo && derr == nil {
+			err = verifyReduce(dest, ver, "ReduceC128")
+		}
+	}
+	if derr != nil {
+		return derr
+	}
+	return err
 }
 
 // AllReduceC128 reduces all values across procs to all procs from orig into dest using given operation.
 // IMPORTANT: orig and dest must be different slices
 // To do an in-place operation, set orig to nil
 func (cm *Comm) AllReduceC128(op Op, dest, orig []complex128) error {
+	cm.countMetric("AllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceC128"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceC128"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
 	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+		sendbuf = bufPtr(orig)
 	} else {
 		sendbuf = C.MPI_IN_PLACE
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.COMPLEX128, op.ToC(), cm.comm), "AllReduceC128")
 }
 
+// IAllReduceC128 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceC128(op Op, dest, orig []complex128) (*Request, error) {
+	cm.countMetric("IAllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "IAllReduceC128"); err != nil {
+		return nil, err
+	}
+	if err := cm.checkOp(op, "IAllReduceC128"); err != nil {
+		return nil, err
+	}
+	r := newRequest(bufPtr(dest))
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = bufPtr(orig)
+		if sendbuf != nil {
+			r.pin.Pin(sendbuf)
+		}
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := bufPtr(dest)
+	return r, Error(C.MPI_Iallreduce(sendbuf, recvbuf, C.int(len(dest)), C.COMPLEX128, op.ToC(), cm.comm, &r.req), "IAllReduceC128")
+}
+
+// ScanC128 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanC128(op Op, dest, orig []complex128) error {
+	cm.countMetric("Scan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ScanC128"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ScanC128"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ScanC128: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	return Error(C.MPI_Scan(bufPtr(orig), bufPtr(dest), C.int(len(orig)), C.COMPLEX128, op.ToC(), cm.comm), "ScanC128")
+}
+
+// ExScanC128 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanC128(op Op, dest, orig []complex128) error {
+	cm.countMetric("ExScan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ExScanC128"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ExScanC128"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ExScanC128: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	recvbuf := bufPtr(dest)
+	if cm.Rank() == 0 {
+		recvbuf = bufPtr(make([]complex128, len(orig)))
+	}
+	return Error(C.MPI_Exscan(bufPtr(orig), recvbuf, C.int(len(orig)), C.COMPLEX128, op.ToC(), cm.comm), "ExScanC128")
+}
+
 // GatherC128 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
-// recvbuf is ignored on all procs except toProc.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherC128(toProc int, dest, orig []complex128) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Gather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	var recvbuf unsafe.Pointer
+	var derr error
+	if cm.Rank() == toProc {
+		np := cm.Size()
+		if len(dest) < np*len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.GatherC128: len(dest) %d < number of procs %d * len(orig) %d", len(dest), np, len(orig))
+			recvbuf = bufPtr(make([]complex128, np*len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.COMPLEX128, recvbuf, C.int(len(orig)), C.COMPLEX128, C.int(toProc), cm.comm), "GatherC128")
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+// GatherInPlaceC128 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceC128(toProc int, buf []complex128) error {
+	cm.countMetric("GatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	isTo := cm.Rank() == toProc
+	np := cm.Size()
+	var err error
+	if isTo && len(buf)%np != 0 {
+		err = errorf("mpi.GatherInPlaceC128: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	if err = cm.rootCheck(toProc, err, "GatherInPlaceC128"); err != nil {
+		return err
+	}
+	ptr := bufPtr(buf)
+	if !isTo {
+		return Error(C.MPI_Gather(ptr, C.int(len(buf)), C.COMPLEX128, nil, 0, C.COMPLEX128, C.int(toProc), cm.comm), "GatherInPlaceC128")
+	}
+	n := len(buf) / np
+	return Error(C.MPI_Gather(C.MPI_IN_PLACE, 0, C.COMPLEX128, ptr, C.int(n), C.COMPLEX128, C.int(toProc), cm.comm), "GatherInPlaceC128")
+}
+
+// GathervC128 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervC128(toProc int, dest, orig []complex128, counts, displs []int) error {
+	cm.countMetric("Gatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	isTo := cm.Rank() == toProc
+	var err error
+	if isTo {
+		np := cm.Size()
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.GathervC128: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[toProc] != len(orig):
+			err = errorf("mpi.GathervC128: counts[%d] %d is not equal to len(orig): %d", toProc, counts[toProc], len(orig))
+		default:
+			err = checkCounts("GathervC128", "dest", len(dest), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(toProc, err, "GathervC128"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var rc, rd *C.int
+	if isTo {
+		recvbuf = bufPtr(dest)
+		cc, cd := cInts(counts), cInts(displs)
+		rc, rd = &cc[0], &cd[0]
 	}
-	return Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.COMPLEX128, recvbuf, C.int(len(orig)), C.COMPLEX128, C.int(toProc), cm.comm), "GatherC128")
+	return Error(C.MPI_Gatherv(sendbuf, C.int(len(orig)), C.COMPLEX128, recvbuf, rc, rd, C.COMPLEX128, C.int(toProc), cm.comm), "GathervC128")
 }
 
 // AllGatherC128 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) AllGatherC128(dest, orig []complex128) error {
-	sendbuf := unsafe.Pointer(&orig[0])
-	recvbuf := unsafe.Pointer(&dest[0])
+	cm.countMetric("AllGather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.COMPLEX128, recvbuf, C.int(len(orig)), C.COMPLEX128, cm.comm), "GatherC128")
 }
 
+// AllGathervC128 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervC128(dest, orig []complex128, counts, displs []int) error {
+	cm.countMetric("AllGatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if displs == nil {
+		displs, _ = Displacements(counts)
+	}
+	// counts and displs are the same on all procs, so all procs return together.
+	if len(counts) != np || len(displs) != np {
+		return errorf("mpi.AllGathervC128: counts and displacements must have length equal to number of procs: %d", np)
+	}
+	if err := checkCounts("AllGathervC128", "dest", len(dest), counts, displs); err != nil {
+		return err
+	}
+	// but len(orig) is only known locally, so a mismatch is sent from
+	// scratch, to keep the other procs from blocking, and returned after.
+	rank := cm.Rank()
+	send := orig
+	var oerr error
+	if counts[rank] != len(orig) {
+		oerr = errorf("mpi.AllGathervC128: counts[%d] %d is not equal to len(orig): %d", rank, counts[rank], len(orig))
+		send = make([]complex128, counts[rank])
+		copy(send, orig)
+	}
+	sendbuf := bufPtr(send)
+	recvbuf := bufPtr(dest)
+	cc, cd := cInts(counts), cInts(displs)
+	err := Error(C.MPI_Allgatherv(sendbuf, C.int(len(send)), C.COMPLEX128, recvbuf, &cc[0], &cd[0], C.COMPLEX128, cm.comm), "AllGathervC128")
+	if err != nil {
+		return err
+	}
+	return oerr
+}
+
+// AllGatherInPlaceC128 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceC128(buf []complex128) error {
+	cm.countMetric("AllGatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(buf)%np != 0 {
+		return errorf("mpi.AllGatherInPlaceC128: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	n := len(buf) / np
+	recvbuf := bufPtr(buf)
+	return Error(C.MPI_Allgather(C.MPI_IN_PLACE, 0, C.COMPLEX128, recvbuf, C.int(n), C.COMPLEX128, cm.comm), "AllGatherInPlaceC128")
+}
+
 // ScatterC128 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
-// sendbuf is ignored on all procs except fmProc.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterC128(fmProc int, dest, orig []complex128) error {
+	cm.countMetric("Scatter", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	isFrom := cm.Rank() == fmProc
+	var err error
+	if isFrom && len(orig) < cm.Size()*len(dest) {
+		err = errorf("mpi.ScatterC128: len(orig) %d < number of procs %d * len(dest) %d", len(orig), cm.Size(), len(dest))
+	}
+	if err = cm.rootCheck(fmProc, err, "ScatterC128"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
-	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+	if isFrom {
+		sendbuf = bufPtr(orig)
+	}
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.COMPLEX128, recvbuf, C.int(len(dest)), C.COMPLEX128, C.int(fmProc), cm.comm), "ScatterC128")
+}
+
+// ScattervC128 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervC128(fmProc int, dest, orig []complex128, counts, displs []int) error {
+	cm.countMetric("Scatterv", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	rank := cm.Rank()
+	np := cm.Size()
+	isFrom := rank == fmProc
+	var err error
+	if isFrom {
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.ScattervC128: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[fmProc] > len(dest):
+			err = errorf("mpi.ScattervC128: len(dest) %d is less than counts[%d]: %d", len(dest), fmProc, counts[fmProc])
+		default:
+			err = checkCounts("ScattervC128", "orig", len(orig), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(fmProc, err, "ScattervC128"); err != nil {
+		return err
+	}
+	// a short dest on another proc cannot be reported before the collective,
+	// so that proc receives into scratch and returns the error after.
+	recv := dest
+	var derr error
+	if !isFrom && len(counts) == np && counts[rank] > len(dest) {
+		derr = errorf("mpi.ScattervC128: len(dest) %d is less than counts[%d]: %d", len(dest), rank, counts[rank])
+		recv = make([]complex128, counts[rank])
+	}
+	var sendbuf unsafe.Pointer
+	recvbuf := bufPtr(recv)
+	var sc, sd *C.int
+	if isFrom {
+		sendbuf = bufPtr(orig)
+		cc, cd := cInts(counts), cInts(displs)
+		sc, sd = &cc[0], &cd[0]
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.COMPLEX128, recvbuf, C.int(len(dest)), C.COMPLEX128, C.int(fmProc), cm.comm), "GatherC128")
+	err = Error(C.MPI_Scatterv(sendbuf, sc, sd, C.COMPLEX128, recvbuf, C.int(len(recv)), C.COMPLEX128, C.int(fmProc), cm.comm), "ScattervC128")
+	if err != nil {
+		return err
+	}
+	return derr
+}
+
+// AllToAllC128 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllC128(dest, orig []complex128) error {
+	cm.countMetric("AllToAll", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(orig)%np != 0 || len(dest)%np != 0 {
+		return errorf("mpi.AllToAllC128: len(orig) %d and len(dest) %d must be evenly divisible by number of procs %d", len(orig), len(dest), np)
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllToAllC128: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	n := len(orig) / np
+	return Error(C.MPI_Alltoall(bufPtr(orig), C.int(n), C.COMPLEX128, bufPtr(dest), C.int(n), C.COMPLEX128, cm.comm), "AllToAllC128")
+}
+
+// AllToAllvC128 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvC128(dest, orig []complex128, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	cm.countMetric("AllToAllv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if sendDispls == nil {
+		sendDispls, _ = Displacements(sendCounts)
+	}
+	if recvDispls == nil {
+		recvDispls, _ = Displacements(recvCounts)
+	}
+	// the counts differ across procs, so any bad ones are reported on all procs.
+	var err error
+	if len(sendCounts) != np || len(recvCounts) != np || len(sendDispls) != np || len(recvDispls) != np {
+		err = errorf("mpi.AllToAllvC128: counts and displacements must have length equal to number of procs: %d", np)
+	} else if err = checkCounts("AllToAllvC128", "orig", len(orig), sendCounts, sendDispls); err == nil {
+		err = checkCounts("AllToAllvC128", "dest", len(dest), recvCounts, recvDispls)
+	}
+	if err = cm.allCheck(err, "AllToAllvC128"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	sc, sd := cInts(sendCounts), cInts(sendDispls)
+	rc, rd := cInts(recvCounts), cInts(recvDispls)
+	return Error(C.MPI_Alltoallv(sendbuf, &sc[0], &sd[0], C.COMPLEX128, recvbuf, &rc[0], &rd[0], C.COMPLEX128, cm.comm), "AllToAllvC128")
 }
 
 // SendC64 sends values to toProc, using given unique tag identifier.
 // This is Blocking. Must have a corresponding Recv call with same tag on toProc, from this proc
 func (cm *Comm) SendC64(toProc int, tag int, vals []complex64) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Send", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Send(buf, C.int(len(vals)), C.COMPLEX64, C.int(toProc), C.int(tag), cm.comm), "SendC64")
 }
 
-// Recv64C64 receives values from proc fmProc, using given unique tag identifier
+// RecvC64 receives values from proc fmProc (which can be AnySource), using given unique tag identifier
 // This is Blocking. Must have a corresponding Send call with same tag on fmProc, to this proc
 func (cm *Comm) RecvC64(fmProc int, tag int, vals []complex64) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Recv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	buf := bufPtr(vals)
 	return Error(C.MPI_Recv(buf, C.int(len(vals)), C.COMPLEX64, C.int(fmProc), C.int(tag), cm.comm, C.StIgnore), "RecvC64")
 }
 
+// IsendC64 starts sending values to toProc, using given unique tag identifier,
+// without blocking.  Must have a corresponding Recv or Irecv call with same tag on toProc,
+// from this proc.  vals must not be modified until the returned Request is complete.
+func (cm *Comm) IsendC64(toProc int, tag int, vals []complex64) (*Request, error) {
+	cm.countMetric("Isend", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRequest(bufPtr(vals))
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Isend(buf, C.int(len(vals)), C.COMPLEX64, C.int(toProc), C.int(tag), cm.comm, &r.req), "IsendC64")
+}
+
+// IrecvC64 starts receiving values from proc fmProc (which can be AnySource),
+// using given unique tag identifier, without blocking.  Must have a corresponding
+// Send or Isend call with same tag on fmProc, to this proc.
+// vals must not be accessed until the returned Request is complete,
+// after which its Status gives the Source, Tag and Count of the message.
+func (cm *Comm) IrecvC64(fmProc int, tag int, vals []complex64) (*Request, error) {
+	cm.countMetric("Irecv", len(vals)*int(unsafe.Sizeof(vals[0])))
+	r := newRecvRequest(bufPtr(vals), C.COMPLEX64)
+	buf := bufPtr(vals)
+	return r, Error(C.MPI_Irecv(buf, C.int(len(vals)), C.COMPLEX64, C.int(fmProc), C.int(tag), cm.comm, &r.req), "IrecvC64")
+}
+
 // BcastC64 broadcasts slice from fmProc to all other procs.
 // All nodes have the same vals after this call, copied from fmProc.
 func (cm *Comm) BcastC64(fmProc int, vals []complex64) error {
-	buf := unsafe.Pointer(&vals[0])
+	cm.countMetric("Bcast", len(vals)*int(unsafe.Sizeof(vals[0])))
+	defer cm.enterCollective()()
+	buf := bufPtr(vals)
 	return Error(C.MPI_Bcast(buf, C.int(len(vals)), C.COMPLEX64, C.int(fmProc), cm.comm), "BcastC64")
 }
 
 // ReduceC64 reduces all values across procs to toProc in orig to dest using given operation.
-// recvbuf is ignored on all procs except toProc.
+// If VerifyReduce is set, the result is checked against an AllReduce.
+// dest is ignored on all procs except toProc, and may be nil on them,
+// but on toProc it must have at least len(orig) values.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ReduceC64(toProc int, op Op, dest, orig []complex64) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Reduce", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ReduceC64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ReduceC64"); err != nil {
+		return err
+	}
+	isTo := cm.Rank() == toProc
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var derr error
+	if isTo {
+		if len(dest) < len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.ReduceC64: len(dest) %d < len(orig) %d", len(dest), len(orig))
+			recvbuf = bufPtr(make([]complex64, len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(orig)), C.COMPLEX64, op.ToC(), C.int(toProc), cm.comm), "ReduceC64")
+	if err == nil && VerifyReduce {
+		ver := make([]complex64, len(orig))
+		err = Error(C.MPI_Allreduce(sendbuf, bufPtr(ver), C.int(len(orig)), C.COMPLEX64, op.ToC(), cm.comm), "ReduceC64")
+		if err == nil && isTo && derr == nil {
+			err = verifyReduce(dest, ver, "ReduceC64")
+		}
 	}
-	return Error(C.MPI_Reduce(sendbuf, recvbuf, C.int(len(dest)), C.COMPLEX64, op.ToC(), C.int(toProc), cm.comm), "ReduceC64")
+	if derr != nil {
+		return derr
+	}
+	return err
 }
 
 // AllReduceC64 reduces all values across procs to all procs from orig into dest using given operation.
 // IMPORTANT: orig and dest must be different slices
 // To do an in-place operation, set orig to nil
 func (cm *Comm) AllReduceC64(op Op, dest, orig []complex64) error {
+	cm.countMetric("AllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "AllReduceC64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceC64"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
 	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+		sendbuf = bufPtr(orig)
 	} else {
 		sendbuf = C.MPI_IN_PLACE
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allreduce(sendbuf, recvbuf, C.int(len(dest)), C.COMPLEX64, op.ToC(), cm.comm), "AllReduceC64")
 }
 
+// IAllReduceC64 starts reducing all values across procs to all procs from orig
+// into dest using given operation, without blocking.  dest must not be accessed,
+// and orig not modified, until the returned Request is complete.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) IAllReduceC64(op Op, dest, orig []complex64) (*Request, error) {
+	cm.countMetric("IAllReduce", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "IAllReduceC64"); err != nil {
+		return nil, err
+	}
+	if err := cm.checkOp(op, "IAllReduceC64"); err != nil {
+		return nil, err
+	}
+	r := newRequest(bufPtr(dest))
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = bufPtr(orig)
+		if sendbuf != nil {
+			r.pin.Pin(sendbuf)
+		}
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := bufPtr(dest)
+	return r, Error(C.MPI_Iallreduce(sendbuf, recvbuf, C.int(len(dest)), C.COMPLEX64, op.ToC(), cm.comm, &r.req), "IAllReduceC64")
+}
+
+// ScanC64 computes the inclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i, e.g., for computing global offsets.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ScanC64(op Op, dest, orig []complex64) error {
+	cm.countMetric("Scan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ScanC64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ScanC64"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ScanC64: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	return Error(C.MPI_Scan(bufPtr(orig), bufPtr(dest), C.int(len(orig)), C.COMPLEX64, op.ToC(), cm.comm), "ScanC64")
+}
+
+// ExScanC64 computes the exclusive prefix reduction of orig across procs
+// into dest using given operation, such that dest on proc i has the reduction
+// of the orig values from procs 0..i-1.  The result on proc 0 is undefined
+// per the MPI standard, so dest is left untouched there.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) ExScanC64(op Op, dest, orig []complex64) error {
+	cm.countMetric("ExScan", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	if err := checkUserOp(op, "ExScanC64"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "ExScanC64"); err != nil {
+		return err
+	}
+	if len(dest) < len(orig) {
+		return errorf("mpi.ExScanC64: len(dest) %d < len(orig) %d", len(dest), len(orig))
+	}
+	recvbuf := bufPtr(dest)
+	if cm.Rank() == 0 {
+		recvbuf = bufPtr(make([]complex64, len(orig)))
+	}
+	return Error(C.MPI_Exscan(bufPtr(orig), recvbuf, C.int(len(orig)), C.COMPLEX64, op.ToC(), cm.comm), "ExScanC64")
+}
+
 // GatherC64 gathers values from all procs into toProc proc, tiled into dest of size np * len(orig).
 // This is inverse of Scatter.
-// recvbuf is ignored on all procs except toProc.
+// dest is ignored on all procs except toProc, and may be nil on them.
 // IMPORTANT: orig and dest must be different slices.
 func (cm *Comm) GatherC64(toProc int, dest, orig []complex64) error {
-	sendbuf := unsafe.Pointer(&orig[0])
+	cm.countMetric("Gather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	var recvbuf unsafe.Pointer
+	var derr error
+	if cm.Rank() == toProc {
+		np := cm.Size()
+		if len(dest) < np*len(orig) {
+			// still take part with a scratch buffer, so the other procs do not block
+			derr = errorf("mpi.GatherC64: len(dest) %d < number of procs %d * len(orig) %d", len(dest), np, len(orig))
+			recvbuf = bufPtr(make([]complex64, np*len(orig)))
+		} else {
+			recvbuf = bufPtr(dest)
+		}
+	}
+	err := Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.COMPLEX64, recvbuf, C.int(len(orig)), C.COMPLEX64, C.int(toProc), cm.comm), "GatherC64")
+	if derr != nil {
+		return derr
+	}
+	return err
+}
+
+// GatherInPlaceC64 gathers values from all procs into toProc proc,
+// tiled by proc into buf of size np * n on toProc, where its own n values
+// must already be in place in buf at offset toProc * n, so they are not copied.
+// On all other procs, buf holds the n values to send.
+// This avoids the need for a separate orig slice on toProc.
+func (cm *Comm) GatherInPlaceC64(toProc int, buf []complex64) error {
+	cm.countMetric("GatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	if len(buf) == 0 {
+		return nil
+	}
+	isTo := cm.Rank() == toProc
+	np := cm.Size()
+	var err error
+	if isTo && len(buf)%np != 0 {
+		err = errorf("mpi.GatherInPlaceC64: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	if err = cm.rootCheck(toProc, err, "GatherInPlaceC64"); err != nil {
+		return err
+	}
+	ptr := bufPtr(buf)
+	if !isTo {
+		return Error(C.MPI_Gather(ptr, C.int(len(buf)), C.COMPLEX64, nil, 0, C.COMPLEX64, C.int(toProc), cm.comm), "GatherInPlaceC64")
+	}
+	n := len(buf) / np
+	return Error(C.MPI_Gather(C.MPI_IN_PLACE, 0, C.COMPLEX64, ptr, C.int(n), C.COMPLEX64, C.int(toProc), cm.comm), "GatherInPlaceC64")
+}
+
+// GathervC64 gathers a variable number of values from all procs into toProc proc,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  dest, counts and displs are ignored on all procs except toProc,
+// where counts[toProc] must equal len(orig).
+// This is inverse of Scatterv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) GathervC64(toProc int, dest, orig []complex64, counts, displs []int) error {
+	cm.countMetric("Gatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	isTo := cm.Rank() == toProc
+	var err error
+	if isTo {
+		np := cm.Size()
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.GathervC64: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[toProc] != len(orig):
+			err = errorf("mpi.GathervC64: counts[%d] %d is not equal to len(orig): %d", toProc, counts[toProc], len(orig))
+		default:
+			err = checkCounts("GathervC64", "dest", len(dest), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(toProc, err, "GathervC64"); err != nil {
+		return err
+	}
+	sendbuf := bufPtr(orig)
 	var recvbuf unsafe.Pointer
-	if dest != nil {
-		recvbuf = unsafe.Pointer(&dest[0])
+	var rc, rd *C.int
+	if isTo {
+		recvbuf = bufPtr(dest)
+		cc, cd := cInts(counts), cInts(displs)
+		rc, rd = &cc[0], &cd[0]
 	}
-	return Error(C.MPI_Gather(sendbuf, C.int(len(orig)), C.COMPLEX64, recvbuf, C.int(len(orig)), C.COMPLEX64, C.int(toProc), cm.comm), "GatherC64")
+	return Error(C.MPI_Gatherv(sendbuf, C.int(len(orig)), C.COMPLEX64, recvbuf, rc, rd, C.COMPLEX64, C.int(toProc), cm.comm), "GathervC64")
 }
 
 // AllGatherC64 gathers values from all procs into all procs,
 // tiled by proc into dest of size np * len(orig).
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) AllGatherC64(dest, orig []complex64) error {
-	sendbuf := unsafe.Pointer(&orig[0])
-	recvbuf := unsafe.Pointer(&dest[0])
+	cm.countMetric("AllGather", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
 	return Error(C.MPI_Allgather(sendbuf, C.int(len(orig)), C.COMPLEX64, recvbuf, C.int(len(orig)), C.COMPLEX64, cm.comm), "GatherC64")
 }
 
+// AllGathervC64 gathers a variable number of values from all procs into all procs,
+// with the counts[i] values from proc i stored into dest starting at displs[i].
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  counts[i] must equal the len(orig) on proc i.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllGathervC64(dest, orig []complex64, counts, displs []int) error {
+	cm.countMetric("AllGatherv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if displs == nil {
+		displs, _ = Displacements(counts)
+	}
+	// counts and displs are the same on all procs, so all procs return together.
+	if len(counts) != np || len(displs) != np {
+		return errorf("mpi.AllGathervC64: counts and displacements must have length equal to number of procs: %d", np)
+	}
+	if err := checkCounts("AllGathervC64", "dest", len(dest), counts, displs); err != nil {
+		return err
+	}
+	// but len(orig) is only known locally, so a mismatch is sent from
+	// scratch, to keep the other procs from blocking, and returned after.
+	rank := cm.Rank()
+	send := orig
+	var oerr error
+	if counts[rank] != len(orig) {
+		oerr = errorf("mpi.AllGathervC64: counts[%d] %d is not equal to len(orig): %d", rank, counts[rank], len(orig))
+		send = make([]complex64, counts[rank])
+		copy(send, orig)
+	}
+	sendbuf := bufPtr(send)
+	recvbuf := bufPtr(dest)
+	cc, cd := cInts(counts), cInts(displs)
+	err := Error(C.MPI_Allgatherv(sendbuf, C.int(len(send)), C.COMPLEX64, recvbuf, &cc[0], &cd[0], C.COMPLEX64, cm.comm), "AllGathervC64")
+	if err != nil {
+		return err
+	}
+	return oerr
+}
+
+// AllGatherInPlaceC64 gathers values from all procs into all procs,
+// tiled by proc into buf of size np * n, where each proc's own n values
+// must already be in place in buf at offset rank * n.
+// This avoids the need for a separate orig slice.
+func (cm *Comm) AllGatherInPlaceC64(buf []complex64) error {
+	cm.countMetric("AllGatherInPlace", len(buf)*int(unsafe.Sizeof(buf[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(buf)%np != 0 {
+		return errorf("mpi.AllGatherInPlaceC64: len(buf) %d is not an even multiple of number of procs: %d", len(buf), np)
+	}
+	n := len(buf) / np
+	recvbuf := bufPtr(buf)
+	return Error(C.MPI_Allgather(C.MPI_IN_PLACE, 0, C.COMPLEX64, recvbuf, C.int(n), C.COMPLEX64, cm.comm), "AllGatherInPlaceC64")
+}
+
 // ScatterC64 scatters values from fmProc to all procs, distributing len(dest) size chunks to
 // each proc from orig slice, which must be of size np * len(dest).  This is inverse of Gather.
-// sendbuf is ignored on all procs except fmProc.
+// orig is ignored, and can be nil, on all procs except fmProc.
 // IMPORTANT: orig and dest must be different slices
 func (cm *Comm) ScatterC64(fmProc int, dest, orig []complex64) error {
+	cm.countMetric("Scatter", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	isFrom := cm.Rank() == fmProc
+	var err error
+	if isFrom && len(orig) < cm.Size()*len(dest) {
+		err = errorf("mpi.ScatterC64: len(orig) %d < number of procs %d * len(dest) %d", len(orig), cm.Size(), len(dest))
+	}
+	if err = cm.rootCheck(fmProc, err, "ScatterC64"); err != nil {
+		return err
+	}
 	var sendbuf unsafe.Pointer
-	if orig != nil {
-		sendbuf = unsafe.Pointer(&orig[0])
+	if isFrom {
+		sendbuf = bufPtr(orig)
+	}
+	recvbuf := bufPtr(dest)
+	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.COMPLEX64, recvbuf, C.int(len(dest)), C.COMPLEX64, C.int(fmProc), cm.comm), "ScatterC64")
+}
+
+// ScattervC64 scatters a variable number of values from fmProc to all procs,
+// with the counts[i] values in orig starting at displs[i] sent to proc i,
+// and received into dest, which must have at least counts[rank] values.
+// If displs is nil, it is computed from counts, for values tiled contiguously
+// in rank order.  orig and displs are ignored on all procs except fmProc,
+// and counts is only used on other procs to check len(dest), if it is given.
+// This is inverse of Gatherv.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) ScattervC64(fmProc int, dest, orig []complex64, counts, displs []int) error {
+	cm.countMetric("Scatterv", len(dest)*int(unsafe.Sizeof(dest[0])))
+	defer cm.enterCollective()()
+	rank := cm.Rank()
+	np := cm.Size()
+	isFrom := rank == fmProc
+	var err error
+	if isFrom {
+		if displs == nil {
+			displs, _ = Displacements(counts)
+		}
+		switch {
+		case len(counts) != np || len(displs) != np:
+			err = errorf("mpi.ScattervC64: counts and displacements must have length equal to number of procs: %d", np)
+		case counts[fmProc] > len(dest):
+			err = errorf("mpi.ScattervC64: len(dest) %d is less than counts[%d]: %d", len(dest), fmProc, counts[fmProc])
+		default:
+			err = checkCounts("ScattervC64", "orig", len(orig), counts, displs)
+		}
+	}
+	if err = cm.rootCheck(fmProc, err, "ScattervC64"); err != nil {
+		return err
+	}
+	// a short dest on another proc cannot be reported before the collective,
+	// so that proc receives into scratch and returns the error after.
+	recv := dest
+	var derr error
+	if !isFrom && len(counts) == np && counts[rank] > len(dest) {
+		derr = errorf("mpi.ScattervC64: len(dest) %d is less than counts[%d]: %d", len(dest), rank, counts[rank])
+		recv = make([]complex64, counts[rank])
+	}
+	var sendbuf unsafe.Pointer
+	recvbuf := bufPtr(recv)
+	var sc, sd *C.int
+	if isFrom {
+		sendbuf = bufPtr(orig)
+		cc, cd := cInts(counts), cInts(displs)
+		sc, sd = &cc[0], &cd[0]
+	}
+	err = Error(C.MPI_Scatterv(sendbuf, sc, sd, C.COMPLEX64, recvbuf, C.int(len(recv)), C.COMPLEX64, C.int(fmProc), cm.comm), "ScattervC64")
+	if err != nil {
+		return err
+	}
+	return derr
+}
+
+// AllToAllC64 sends the i-th chunk of orig to proc i, and receives the chunk
+// from proc i into the i-th chunk of dest, e.g., for a distributed transpose,
+// where the chunk size is len(orig) / np.  len(orig) and len(dest) must be
+// the same, and evenly divisible by the number of procs.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllC64(dest, orig []complex64) error {
+	cm.countMetric("AllToAll", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if len(orig)%np != 0 || len(dest)%np != 0 {
+		return errorf("mpi.AllToAllC64: len(orig) %d and len(dest) %d must be evenly divisible by number of procs %d", len(orig), len(dest), np)
+	}
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllToAllC64: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	n := len(orig) / np
+	return Error(C.MPI_Alltoall(bufPtr(orig), C.int(n), C.COMPLEX64, bufPtr(dest), C.int(n), C.COMPLEX64, cm.comm), "AllToAllC64")
+}
+
+// AllToAllvC64 sends a variable number of values from each proc to every other proc:
+// sendCounts[i] values starting at orig[sendDispls[i]] are sent to proc i,
+// and recvCounts[i] values from proc i are received into dest starting at recvDispls[i].
+// If sendDispls or recvDispls are nil, they are computed from the counts,
+// for values tiled contiguously in rank order.  The counts must be consistent
+// across procs: sendCounts[j] on proc i == recvCounts[i] on proc j.
+// IMPORTANT: orig and dest must be different slices
+func (cm *Comm) AllToAllvC64(dest, orig []complex64, sendCounts, sendDispls, recvCounts, recvDispls []int) error {
+	cm.countMetric("AllToAllv", len(orig)*int(unsafe.Sizeof(orig[0])))
+	defer cm.enterCollective()()
+	np := cm.Size()
+	if sendDispls == nil {
+		sendDispls, _ = Displacements(sendCounts)
+	}
+	if recvDispls == nil {
+		recvDispls, _ = Displacements(recvCounts)
+	}
+	// the counts differ across procs, so any bad ones are reported on all procs.
+	var err error
+	if len(sendCounts) != np || len(recvCounts) != np || len(sendDispls) != np || len(recvDispls) != np {
+		err = errorf("mpi.AllToAllvC64: counts and displacements must have length equal to number of procs: %d", np)
+	} else if err = checkCounts("AllToAllvC64", "orig", len(orig), sendCounts, sendDispls); err == nil {
+		err = checkCounts("AllToAllvC64", "dest", len(dest), recvCounts, recvDispls)
+	}
+	if err = cm.allCheck(err, "AllToAllvC64"); err != nil {
+		return err
 	}
-	recvbuf := unsafe.Pointer(&dest[0])
-	return Error(C.MPI_Scatter(sendbuf, C.int(len(dest)), C.COMPLEX64, recvbuf, C.int(len(dest)), C.COMPLEX64, C.int(fmProc), cm.comm), "GatherC64")
+	sendbuf := bufPtr(orig)
+	recvbuf := bufPtr(dest)
+	sc, sd := cInts(sendCounts), cInts(sendDispls)
+	rc, rd := cInts(recvCounts), cInts(recvDispls)
+	return Error(C.MPI_Alltoallv(sendbuf, &sc[0], &sd[0], C.COMPLEX64, recvbuf, &rc[0], &rd[0], C.COMPLEX64, cm.comm), "AllToAllvC64")
 }
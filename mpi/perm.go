@@ -0,0 +1,22 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import "math/rand"
+
+// SharedPerm returns a random permutation of the integers [0, n) that is
+// identical on all procs, e.g., for a global shuffle of data or consistent
+// sampling across procs.  The permutation is generated on the Root proc
+// from a new random source with given seed, and broadcast to the other
+// procs, so it does not depend on the state of any random number generator
+// on the other procs, or on their seed.  It must be called on all procs.
+func (cm *Comm) SharedPerm(n int, seed int64) ([]int, error) {
+	if n < 0 {
+		return nil, errorf("mpi.SharedPerm: n must be >= 0: %d", n)
+	}
+	return RootComputeBcast(cm, func() []int {
+		return rand.New(rand.NewSource(seed)).Perm(n)
+	})
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// ExchangePlan implements the gather-decide-broadcast coordination pattern,
+// e.g., for a setup phase where each proc reports its capabilities
+// (memory, device count, etc) and the Root proc decides how to allocate
+// work accordingly.  Each proc's local bytes are gathered to Root, where plan
+// is called with the bytes from each proc, in rank order, and the resulting
+// plan bytes are broadcast to, and returned on, all procs.
+// Each proc can have a different amount of local bytes, and plan can return
+// any amount.  plan is only called on Root, and can be nil on other procs.
+func (cm *Comm) ExchangePlan(local []byte, plan func(perRank [][]byte) []byte) ([]byte, error) {
+	combined, offsets, err := cm.GatherCheckpoint(local)
+	if err != nil {
+		return nil, err
+	}
+	var res []byte
+	isRoot := cm.Rank() == Root
+	if isRoot {
+		np := len(offsets) - 1
+		perRank := make([][]byte, np)
+		for r := range perRank {
+			perRank[r] = combined[offsets[r]:offsets[r+1]]
+		}
+		res = plan(perRank)
+	}
+	if cm.Size() == 1 {
+		return res, nil
+	}
+	n := []int{len(res)}
+	err = cm.BcastInt(Root, n)
+	if err != nil {
+		return nil, err
+	}
+	if !isRoot {
+		res = make([]byte, n[0])
+	}
+	if n[0] == 0 {
+		return res, nil
+	}
+	return res, cm.BcastU8(Root, res)
+}
@@ -38,7 +38,7 @@ func Println(fs ...any) {
 	if WorldRank() > 0 {
 		AllPrintln(fs...)
 	} else {
-		fmt.Println(fs)
+		fmt.Println(fs...)
 	}
 }
 
@@ -50,3 +50,21 @@ func AllPrintln(fs ...any) {
 	fsa[0] = fmt.Sprintf("P%d: ", WorldRank())
 	fmt.Println(fsa...)
 }
+
+// Logf does fmt.Printf only on the 0 rank node of this communicator,
+// with the communicator's Name (if set) and its local rank printed first.
+// PrintAllProcs causes it to print on all procs, as with Printf.
+// This is best for debugging with multiple communicators,
+// where the World rank used by Printf is not as informative.
+func (cm *Comm) Logf(fs string, pars ...any) {
+	rank := cm.Rank()
+	if !PrintAllProcs && rank > 0 {
+		return
+	}
+	pfx := fmt.Sprintf("P%d: ", rank)
+	if nm := cm.Name(); nm != "" {
+		pfx = nm + " " + pfx
+	}
+	fmt.Print(pfx)
+	fmt.Printf(fs, pars...)
+}
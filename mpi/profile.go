@@ -0,0 +1,47 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// GatherProfile gathers the named timing values (e.g., seconds spent in each
+// phase of processing, as measured with Wtime) from all procs to the Root proc,
+// returning a map from each name to the times for that name on each proc,
+// in rank order, for reporting the min, max, and mean per phase.
+// All procs must use the same names in the same order.
+// The map is only returned on Root, and is nil on other procs.
+func (cm *Comm) GatherProfile(names []string, times []float64) (map[string][]float64, error) {
+	n := len(names)
+	if len(times) != n {
+		return nil, errorf("mpi.GatherProfile: len(times) %d != len(names) %d", len(times), n)
+	}
+	np := cm.Size()
+	var all []float64
+	if np == 1 {
+		all = times
+	} else {
+		if err := cm.AssertSameInt(n); err != nil {
+			return nil, err
+		}
+		if cm.Rank() == Root {
+			all = make([]float64, np*n)
+		}
+		if n > 0 {
+			if err := cm.GatherF64(Root, all, times); err != nil {
+				return nil, err
+			}
+		}
+		if cm.Rank() != Root {
+			return nil, nil
+		}
+	}
+	prof := make(map[string][]float64, n)
+	for i, nm := range names {
+		pt := make([]float64, np)
+		for p := range pt {
+			pt[p] = all[p*n+i]
+		}
+		prof[nm] = pt
+	}
+	return prof, nil
+}
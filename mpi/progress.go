@@ -0,0 +1,104 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// progressState holds the state for ProgressSum.
+type progressState struct {
+	// comm is a private duplicate of the communicator, so that the
+	// pending sums do not interfere with other collective operations.
+	comm *Comm
+
+	// req is the pending sum request, if any.
+	req *Request
+
+	// local and sum are the buffers for the pending sum.
+	local, sum []int
+
+	// total is the last completed sum.
+	total int
+
+	// started is the number of sums started.
+	started int
+}
+
+// ProgressSum returns the total across all procs of the local progress counts
+// (e.g., number of trials completed), without blocking, for progress reporting.
+// Each call checks whether the previously started non-blocking sum has completed,
+// in which case its result becomes the current total, and a new sum of the current
+// local value is started.  Otherwise, the last known total is returned.
+// Thus, the total lags behind the current local counts, and is 0 until the first
+// sum completes, but faster procs are never stalled waiting for slower ones.
+// All procs must call ProgressDone at the end, to complete any pending sum.
+func (cm *Comm) ProgressSum(local int) (int, error) {
+	if cm.Size() == 1 {
+		return local, nil
+	}
+	ps := cm.progress
+	if ps == nil {
+		pc, err := cm.dup()
+		if err != nil {
+			return 0, err
+		}
+		ps = &progressState{comm: pc, local: make([]int, 1), sum: make([]int, 1)}
+		cm.progress = ps
+	}
+	if ps.req != nil {
+		done, err := ps.req.Test()
+		if err != nil || !done {
+			return ps.total, err
+		}
+		ps.total = ps.sum[0]
+		ps.req = nil
+	}
+	return ps.total, ps.start(local)
+}
+
+// start starts a new non-blocking sum of given local value.
+func (ps *progressState) start(local int) error {
+	ps.local[0] = local
+	req, err := ps.comm.IAllReduceInt(OpSum, ps.sum, ps.local)
+	if err != nil {
+		return err
+	}
+	ps.req = req
+	ps.started++
+	return nil
+}
+
+// ProgressDone completes any pending ProgressSum, and returns the final,
+// exact total of the given local counts across all procs.  It must be called
+// on all procs after the last call to ProgressSum, and before Finalize.
+func (cm *Comm) ProgressDone(local int) (int, error) {
+	ps := cm.progress
+	if ps != nil {
+		// procs can differ in the number of sums started, by at most one,
+		// so any procs that are behind start one more to complete it.
+		mx := []int{0}
+		err := cm.AllReduceInt(OpMax, mx, []int{ps.started})
+		if err != nil {
+			return 0, err
+		}
+		if ps.req != nil {
+			if err := ps.req.Wait(); err != nil {
+				return 0, err
+			}
+		}
+		if ps.started < mx[0] {
+			if err := ps.start(local); err != nil {
+				return 0, err
+			}
+			if err := ps.req.Wait(); err != nil {
+				return 0, err
+			}
+		}
+		cm.progress = nil
+	}
+	if cm.Size() == 1 {
+		return local, nil
+	}
+	tot := []int{0}
+	err := cm.AllReduceInt(OpSum, tot, []int{local})
+	return tot[0], err
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+
+package mpi
+
+/*
+#cgo pkg-config: ompi
+#include "mpi.h"
+
+extern MPI_Datatype FLOAT32;
+extern MPI_Datatype FLOAT64;
+*/
+import "C"
+
+// ReduceLocalF32 combines the values in orig into dest using given operation,
+// on this proc only, without any communication (i.e., dest = dest op orig),
+// using the same operation semantics as the collective reduce methods,
+// including user-defined Ops from NewOp.
+// IMPORTANT: orig and dest must be different slices, of the same length.
+func ReduceLocalF32(op Op, dest, orig []float32) error {
+	if len(dest) != len(orig) {
+		return errorf("mpi.ReduceLocalF32: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	if len(dest) == 0 {
+		return nil
+	}
+	return Error(C.MPI_Reduce_local(bufPtr(orig), bufPtr(dest), C.int(len(dest)), C.FLOAT32, op.ToC()), "ReduceLocalF32")
+}
+
+// ReduceLocalF64 combines the values in orig into dest using given operation,
+// on this proc only, without any communication (i.e., dest = dest op orig),
+// using the same operation semantics as the collective reduce methods.
+// IMPORTANT: orig and dest must be different slices, of the same length.
+func ReduceLocalF64(op Op, dest, orig []float64) error {
+	if len(dest) != len(orig) {
+		return errorf("mpi.ReduceLocalF64: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	if err := checkUserOp(op, "ReduceLocalF64"); err != nil {
+		return err
+	}
+	if len(dest) == 0 {
+		return nil
+	}
+	return Error(C.MPI_Reduce_local(bufPtr(orig), bufPtr(dest), C.int(len(dest)), C.FLOAT64, op.ToC()), "ReduceLocalF64")
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !mpi
+
+package mpi
+
+// ReduceLocalF32 combines the values in orig into dest using given operation,
+// on this proc only, without any communication (i.e., dest = dest op orig),
+// using the same operation semantics as the collective reduce methods.
+// Only OpSum, OpProd, OpMax, OpMin, and user-defined Ops from NewOp
+// are supported in this dummy version.
+// IMPORTANT: orig and dest must be different slices, of the same length.
+func ReduceLocalF32(op Op, dest, orig []float32) error {
+	if slot, ok := userOpSlot(op); ok {
+		if len(dest) != len(orig) {
+			return errorf("mpi.ReduceLocalF32: len(dest) %d != len(orig) %d", len(dest), len(orig))
+		}
+		userOpFunc(slot)(orig, dest)
+		return nil
+	}
+	return reduceLocal(op, dest, orig, "ReduceLocalF32")
+}
+
+// ReduceLocalF64 combines the values in orig into dest using given operation,
+// on this proc only, without any communication (i.e., dest = dest op orig),
+// using the same operation semantics as the collective reduce methods.
+// Only OpSum, OpProd, OpMax, and OpMin are supported in this dummy version.
+// IMPORTANT: orig and dest must be different slices, of the same length.
+func ReduceLocalF64(op Op, dest, orig []float64) error {
+	if err := checkUserOp(op, "ReduceLocalF64"); err != nil {
+		return err
+	}
+	return reduceLocal(op, dest, orig, "ReduceLocalF64")
+}
+
+// reduceLocal implements ReduceLocal using combineOp.
+func reduceLocal[T float32 | float64](op Op, dest, orig []T, ctxt string) error {
+	if len(dest) != len(orig) {
+		return errorf("mpi.%s: len(dest) %d != len(orig) %d", ctxt, len(dest), len(orig))
+	}
+	switch op {
+	case OpSum, OpProd, OpMax, OpMin:
+	default:
+		return errorf("mpi.%s: Op %d is not supported", ctxt, op)
+	}
+	combineOp(op, dest, orig)
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+
+package mpi
+
+/*
+#cgo pkg-config: ompi
+#include "mpi.h"
+
+extern MPI_Status* StIgnore;
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+// Request is a handle for a non-blocking communication operation,
+// as returned by the Isend and Irecv methods.  The operation must be
+// completed by calling Wait (or until Test returns true) before
+// the buffer used in the operation can be accessed or reused.
+type Request struct {
+	req C.MPI_Request
+
+	// pin keeps the buffer used in the operation pinned in memory
+	// until the operation is complete.
+	pin runtime.Pinner
+
+	// dt is the datatype of the receive buffer for receive operations,
+	// for the Count of the Status, and MPI_DATATYPE_NULL otherwise.
+	dt C.MPI_Datatype
+
+	// status is the Status of the operation once it has completed.
+	status Status
+}
+
+// newRequest returns a new Request for an operation on the buffer
+// starting at given pointer, which is pinned until the request is complete.
+// ptr is nil for an empty buffer, which is not pinned.
+func newRequest(ptr unsafe.Pointer) *Request {
+	r := &Request{dt: C.MPI_DATATYPE_NULL}
+	if ptr != nil {
+		r.pin.Pin(ptr)
+	}
+	return r
+}
+
+// newRecvRequest returns a new Request as in newRequest, for a receive
+// into a buffer of given datatype, so that its Status has the Count.
+func newRecvRequest(ptr unsafe.Pointer, dt C.MPI_Datatype) *Request {
+	r := newRequest(ptr)
+	r.dt = dt
+	return r
+}
+
+// newStatusBuf returns an MPI status for a call that completes operations,
+// with MPI_ERROR initialized, as it is only set by calls that complete
+// multiple operations, and then only when one of them has failed.
+func newStatusBuf() C.MPI_Status {
+	return C.MPI_Status{MPI_ERROR: C.MPI_SUCCESS}
+}
+
+// complete records the Status of the completed operation from given
+// MPI status, and unpins its buffer.
+func (r *Request) complete(st *C.MPI_Status) {
+	r.status = newStatus(st, r.dt)
+	r.pin.Unpin()
+}
+
+// Status returns the Status of the operation, which for an Irecv gives
+// the Source, Tag and Count of the message received, e.g., when receiving
+// from AnySource, or into a buffer larger than the message.
+// It is only valid once the operation has completed.
+func (r *Request) Status() Status {
+	return r.status
+}
+
+// Wait blocks until the operation has completed.
+// It is safe to call Wait on an already completed Request.
+func (r *Request) Wait() error {
+	if r.req == C.MPI_REQUEST_NULL {
+		return nil
+	}
+	st := newStatusBuf()
+	err := Error(C.MPI_Wait(&r.req, &st), "Wait")
+	r.complete(&st)
+	return err
+}
+
+// Test returns true if the operation has completed, without blocking.
+func (r *Request) Test() (bool, error) {
+	if r.req == C.MPI_REQUEST_NULL {
+		return true, nil
+	}
+	var flag C.int
+	st := newStatusBuf()
+	err := Error(C.MPI_Test(&r.req, &flag, &st), "Test")
+	if flag != 0 {
+		r.complete(&st)
+	}
+	return flag != 0, err
+}
+
+// cRequests returns the MPI requests for given Requests, in a contiguous
+// array for passing to MPI, with nil entries as MPI_REQUEST_NULL.
+func cRequests(reqs []*Request) []C.MPI_Request {
+	cr := make([]C.MPI_Request, len(reqs))
+	for i, r := range reqs {
+		if r == nil {
+			cr[i] = C.MPI_REQUEST_NULL
+		} else {
+			cr[i] = r.req
+		}
+	}
+	return cr
+}
+
+// Waitall blocks until all of the given operations have completed.
+// nil entries are treated as already complete.  If any of the operations
+// failed, the error of the first one is returned, with its index.
+func Waitall(reqs []*Request) error {
+	if len(reqs) == 0 {
+		return nil
+	}
+	cr := cRequests(reqs)
+	active := make([]bool, len(cr))
+	sts := make([]C.MPI_Status, len(reqs))
+	for i := range sts {
+		active[i] = cr[i] != C.MPI_REQUEST_NULL
+		sts[i] = newStatusBuf()
+	}
+	ec := C.MPI_Waitall(C.int(len(cr)), &cr[0], &sts[0])
+	for i, r := range reqs {
+		if r == nil {
+			continue
+		}
+		r.req = cr[i]
+		// requests still pending after an error in another one remain active
+		if active[i] && r.req == C.MPI_REQUEST_NULL {
+			r.complete(&sts[i])
+		}
+	}
+	if ec == C.MPI_ERR_IN_STATUS {
+		for i, r := range reqs {
+			if r != nil && active[i] && r.status.Error != nil {
+				return fmt.Errorf("mpi.Waitall: request %d: %w", i, r.status.Error)
+			}
+		}
+	}
+	return Error(ec, "Waitall")
+}
+
+// Waitany blocks until any one of the given operations has completed,
+// returning its index.  nil entries are treated as already complete,
+// and are not returned: if all entries are nil, or already completed,
+// the index is -1.
+func Waitany(reqs []*Request) (index int, err error) {
+	if len(reqs) == 0 {
+		return -1, nil
+	}
+	cr := cRequests(reqs)
+	var idx C.int
+	st := newStatusBuf()
+	err = Error(C.MPI_Waitany(C.int(len(cr)), &cr[0], &idx, &st), "Waitany")
+	if idx == C.MPI_UNDEFINED {
+		return -1, err
+	}
+	r := reqs[idx]
+	r.req = cr[idx]
+	r.complete(&st)
+	return int(idx), err
+}
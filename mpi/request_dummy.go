@@ -0,0 +1,53 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !mpi
+
+package mpi
+
+// Request is a handle for a non-blocking communication operation,
+// as returned by the Isend and Irecv methods.  The operation must be
+// completed by calling Wait (or until Test returns true) before
+// the buffer used in the operation can be accessed or reused.
+type Request struct {
+}
+
+// Status returns the Status of the operation, which for an Irecv gives
+// the Source, Tag and Count of the message received, e.g., when receiving
+// from AnySource, or into a buffer larger than the message.
+// It is only valid once the operation has completed.
+func (r *Request) Status() Status {
+	return Status{}
+}
+
+// Wait blocks until the operation has completed.
+// It is safe to call Wait on an already completed Request.
+func (r *Request) Wait() error {
+	return nil
+}
+
+// Test returns true if the operation has completed, without blocking.
+func (r *Request) Test() (bool, error) {
+	return true, nil
+}
+
+// Waitall blocks until all of the given operations have completed.
+// nil entries are treated as already complete.  If any of the operations
+// failed, the error of the first one is returned, with its index.
+func Waitall(reqs []*Request) error {
+	return nil
+}
+
+// Waitany blocks until any one of the given operations has completed,
+// returning its index.  nil entries are treated as already complete,
+// and are not returned: if all entries are nil, or already completed,
+// the index is -1.
+func Waitany(reqs []*Request) (index int, err error) {
+	for i, r := range reqs {
+		if r != nil {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
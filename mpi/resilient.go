@@ -0,0 +1,26 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build ulfm
+
+package mpi
+
+import "fmt"
+
+// DegradedError is returned by the Resilient methods when one or more procs
+// failed during the operation, which was then completed over the surviving
+// procs only.  The Comm has been shrunk to contain only the surviving procs,
+// so Rank and Size may have changed.
+type DegradedError struct {
+
+	// Lost are the ranks, in the Comm prior to shrinking, of the procs that failed.
+	Lost []int
+
+	// Size is the number of surviving procs.
+	Size int
+}
+
+func (e *DegradedError) Error() string {
+	return fmt.Sprintf("mpi: procs failed: %v, operation completed over %d surviving procs", e.Lost, e.Size)
+}
@@ -0,0 +1,17 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !mpi && ulfm
+
+package mpi
+
+// AllReduceF32Resilient reduces all values across procs to all procs from orig
+// into dest using given operation, like AllReduceF32, except that if any procs
+// fail during the operation, the Comm is revoked and shrunk to the surviving
+// procs, and the reduction is completed over the survivors, returning a
+// *DegradedError listing the failed ranks.
+// IMPORTANT: orig and dest must be different slices.
+func (cm *Comm) AllReduceF32Resilient(op Op, dest, orig []float32) error {
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi && ulfm
+
+package mpi
+
+/*
+#cgo pkg-config: ompi
+#include "mpi.h"
+#include "mpi-ext.h"
+
+extern MPI_Comm World;
+extern MPI_Datatype FLOAT32;
+*/
+import "C"
+
+import "log"
+
+// this file requires an MPI build with ULFM (User-Level Failure Mitigation)
+// support, such as OpenMPI 5, and is only included with the ulfm build tag.
+
+// AllReduceF32Resilient reduces all values across procs to all procs from orig
+// into dest using given operation, like AllReduceF32, except that if any procs
+// fail during the operation, the Comm is revoked and shrunk to the surviving
+// procs, and the reduction is completed over the survivors, returning a
+// *DegradedError listing the failed ranks.  The job can then continue with
+// the reduced Comm, instead of being aborted.  Errors are returned instead of
+// aborting for this Comm from the first call on.
+// IMPORTANT: orig and dest must be different slices (in-place is not supported,
+// because orig is needed to redo the operation).
+func (cm *Comm) AllReduceF32Resilient(op Op, dest, orig []float32) error {
+	if len(dest) != len(orig) {
+		return errorf("mpi.AllReduceF32Resilient: len(dest) %d != len(orig) %d", len(dest), len(orig))
+	}
+	C.MPI_Comm_set_errhandler(cm.comm, C.MPI_ERRORS_RETURN)
+	var lost []int
+	for {
+		ec := C.MPI_Allreduce(bufPtr(orig), bufPtr(dest), C.int(len(dest)), C.FLOAT32, op.ToC(), cm.comm)
+		// all survivors must agree on success, as failure may not be detected everywhere
+		flag := C.int(0)
+		if ec == C.MPI_SUCCESS {
+			flag = 1
+		}
+		aec := C.MPIX_Comm_agree(cm.comm, &flag)
+		if aec == C.MPI_SUCCESS && flag == 1 {
+			break
+		}
+		if ec == C.MPI_SUCCESS {
+			ec = aec
+		}
+		if !isProcFailure(ec) {
+			return Error(ec, "AllReduceF32Resilient")
+		}
+		ls, err := cm.shrink()
+		if err != nil {
+			return err
+		}
+		log.Printf("mpi.AllReduceF32Resilient: procs failed: %v, continuing with %d procs\n", ls, cm.Size())
+		lost = append(lost, ls...)
+	}
+	if len(lost) > 0 {
+		return &DegradedError{Lost: lost, Size: cm.Size()}
+	}
+	return nil
+}
+
+// isProcFailure returns true if the MPI error code indicates
+// a proc failure or a revoked communicator.
+func isProcFailure(ec C.int) bool {
+	var cls C.int
+	C.MPI_Error_class(ec, &cls)
+	return cls == C.MPIX_ERR_PROC_FAILED || cls == C.MPIX_ERR_REVOKED
+}
+
+// shrink revokes this Comm and replaces it with one containing only the
+// surviving procs, returning the ranks of the failed procs in the prior Comm.
+func (cm *Comm) shrink() ([]int, error) {
+	C.MPIX_Comm_revoke(cm.comm)
+	var nc C.MPI_Comm
+	err := Error(C.MPIX_Comm_shrink(cm.comm, &nc), "Comm_shrink")
+	if err != nil {
+		return nil, err
+	}
+	var ng C.MPI_Group
+	err = Error(C.MPI_Comm_group(nc, &ng), "Comm_group")
+	if err != nil {
+		return nil, err
+	}
+	var n C.int
+	C.MPI_Group_size(cm.group, &n)
+	ranks := make([]C.int, n)
+	for i := range ranks {
+		ranks[i] = C.int(i)
+	}
+	nranks := make([]C.int, n)
+	C.MPI_Group_translate_ranks(cm.group, n, &ranks[0], ng, &nranks[0])
+	var lost []int
+	for i, r := range nranks {
+		if r == C.MPI_UNDEFINED {
+			lost = append(lost, i)
+		}
+	}
+	if cm.comm != C.World {
+		C.MPI_Comm_free(&cm.comm)
+	}
+	C.MPI_Group_free(&cm.group)
+	cm.comm = nc
+	cm.group = ng
+	cm.Refresh()
+	C.MPI_Comm_set_errhandler(cm.comm, C.MPI_ERRORS_RETURN)
+	return lost, nil
+}
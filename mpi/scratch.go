@@ -0,0 +1,20 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// ScratchF32 returns a scratch buffer of n float32 values cached on this Comm,
+// which is only reallocated when it needs to grow, for use as the separate
+// orig or dest slice required by Reduce and AllReduce, avoiding an
+// allocation on every call when a reduction is conceptually in-place:
+// copy the values into the scratch buffer and reduce from it into the
+// original slice.  The contents are not initialized, and the same memory
+// is returned by each call, so the buffer must not be used by concurrent
+// operations on this Comm, or retained across calls.
+func (cm *Comm) ScratchF32(n int) []float32 {
+	if cap(cm.scratchF32) < n {
+		cm.scratchF32 = make([]float32, n)
+	}
+	return cm.scratchF32[:n]
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import "math"
+
+// AllReduceCountMean sums the integer localCount across all procs
+// (e.g., a number of events counted on each proc), returning the total
+// and the mean count per proc (total / Size) as a float64, on all procs.
+func (cm *Comm) AllReduceCountMean(localCount int) (mean float64, total int, err error) {
+	tot := []int{localCount}
+	if cm.Size() > 1 {
+		err = cm.AllReduceInt(OpSum, tot, []int{localCount})
+		if err != nil {
+			return
+		}
+	}
+	total = tot[0]
+	mean = float64(total) / float64(cm.Size())
+	return
+}
+
+// AllReduceCountMax returns the maximum of the integer localCount
+// across all procs, on all procs.
+func (cm *Comm) AllReduceCountMax(localCount int) (int, error) {
+	return cm.allReduceCount(OpMax, localCount)
+}
+
+// AllReduceCountMin returns the minimum of the integer localCount
+// across all procs, on all procs.
+func (cm *Comm) AllReduceCountMin(localCount int) (int, error) {
+	return cm.allReduceCount(OpMin, localCount)
+}
+
+// allReduceCount all-reduces a single int count using given op.
+func (cm *Comm) allReduceCount(op Op, localCount int) (int, error) {
+	if cm.Size() == 1 {
+		return localCount, nil
+	}
+	cv := []int{0}
+	err := cm.AllReduceInt(op, cv, []int{localCount})
+	return cv[0], err
+}
+
+// FederatedAvgF32 replaces localModel with the average of the localModel
+// values across all procs, weighted by the localSamples count on each proc
+// (e.g., the number of training samples that produced each local model),
+// as in federated averaging, by summing localModel * localSamples and the
+// localSamples counts across procs, and dividing.  Returns an error if the
+// total number of samples is 0.
+func (cm *Comm) FederatedAvgF32(localModel []float32, localSamples int) error {
+	_, total, err := cm.AllReduceCountMean(localSamples)
+	if err != nil {
+		return err
+	}
+	if total == 0 {
+		return errorf("mpi.FederatedAvgF32: total number of samples across procs is 0")
+	}
+	if cm.Size() == 1 || len(localModel) == 0 {
+		return nil
+	}
+	wt := make([]float32, len(localModel))
+	for i, v := range localModel {
+		wt[i] = v * float32(localSamples)
+	}
+	err = cm.AllReduceF32(OpSum, localModel, wt)
+	if err != nil {
+		return err
+	}
+	norm := 1 / float32(total)
+	for i := range localModel {
+		localModel[i] *= norm
+	}
+	return nil
+}
+
+// AllReduceHistogram computes the histogram of the local values into the
+// given number of equal-width bins spanning the range min to max,
+// and sums the bin counts across all procs, returning the global histogram
+// on all procs.  Values outside of the range are counted in the first or
+// last bin, and NaN values are ignored.
+func (cm *Comm) AllReduceHistogram(values []float32, bins int, min, max float32) ([]int, error) {
+	if bins < 1 || !(max > min) {
+		return nil, errorf("mpi.AllReduceHistogram: bins %d must be > 0 and max %g must be > min %g", bins, max, min)
+	}
+	local := make([]int, bins)
+	scale := float32(bins) / (max - min)
+	for _, v := range values {
+		if v != v { // NaN
+			continue
+		}
+		b := int((v - min) * scale)
+		if v < min || b < 0 {
+			b = 0
+		} else if b >= bins {
+			b = bins - 1
+		}
+		local[b]++
+	}
+	if cm.Size() == 1 {
+		return local, nil
+	}
+	hist := make([]int, bins)
+	err := cm.AllReduceInt(OpSum, hist, local)
+	return hist, err
+}
+
+// AllNormF32 returns the global Lp norm of a vector that is distributed
+// across procs, with each proc holding its own portion in buf, on all procs,
+// e.g., for gradient clipping.  The local sum of |x|^p is computed on each
+// proc, in float64, and summed across procs, returning the p-th root of the sum.
+// p must be > 0: p = 2 is the standard Euclidean (L2) norm, and p = +Inf
+// returns the maximum absolute value across all procs.
+func (cm *Comm) AllNormF32(buf []float32, p float64) (float64, error) {
+	if !(p > 0) {
+		return 0, errorf("mpi.AllNormF32: p must be > 0, not: %g", p)
+	}
+	op := OpSum
+	sum := 0.0
+	switch {
+	case math.IsInf(p, 1):
+		op = OpMax
+		for _, v := range buf {
+			sum = max(sum, math.Abs(float64(v)))
+		}
+	case p == 2:
+		for _, v := range buf {
+			sum += float64(v) * float64(v)
+		}
+	default:
+		for _, v := range buf {
+			sum += math.Pow(math.Abs(float64(v)), p)
+		}
+	}
+	if cm.Size() > 1 {
+		res := []float64{0}
+		err := cm.AllReduceF64(op, res, []float64{sum})
+		if err != nil {
+			return 0, err
+		}
+		sum = res[0]
+	}
+	switch {
+	case op == OpMax:
+		return sum, nil
+	case p == 2:
+		return math.Sqrt(sum), nil
+	}
+	return math.Pow(sum, 1/p), nil
+}
+
+// AllReduceSumSkipNaNF32 sums buf in place across all procs, like AllReduceF32
+// with OpSum, except that any NaN or Inf values are first replaced with 0
+// (the identity for the sum), so that an occasional bad value on one proc
+// does not make the entire result NaN.  Returns nanCount, the total number of
+// values that were skipped across all procs: each skipped value is missing
+// from the sum, so any normalization of the result must account for it,
+// e.g., a mean over n total values should be divided by n - nanCount, not n.
+func (cm *Comm) AllReduceSumSkipNaNF32(buf []float32) (nanCount int, err error) {
+	for i, v := range buf {
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			buf[i] = 0
+			nanCount++
+		}
+	}
+	if cm.Size() == 1 {
+		return
+	}
+	if len(buf) > 0 {
+		err = cm.AllReduceF32(OpSum, buf, nil)
+		if err != nil {
+			return
+		}
+	}
+	return cm.allReduceCount(OpSum, nanCount)
+}
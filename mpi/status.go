@@ -0,0 +1,58 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+
+package mpi
+
+/*
+#cgo pkg-config: ompi
+#include "mpi.h"
+*/
+import "C"
+
+// Status is the status of a completed receive (or probed message),
+// as returned by functions that report which message was received.
+type Status struct {
+
+	// Source is the rank of the proc that sent the message.
+	Source int
+
+	// Tag is the tag of the message.
+	Tag int
+
+	// Count is the number of values of the datatype of the receive
+	// operation in the message, or -1 if the message size is not
+	// an integer multiple of that datatype size, or for operations
+	// other than receives.
+	Count int
+
+	// Error is the error for this message, which is only set when
+	// completing multiple operations at once.
+	Error error
+}
+
+// newStatus returns the Status for given MPI status, using given datatype
+// to compute the Count.  MPI_Get_count needs the datatype because the status
+// only records the number of bytes received, so all status-returning functions
+// must use this with the datatype of their receive buffer.  dt is
+// MPI_DATATYPE_NULL for operations other than receives, with a Count of -1.
+func newStatus(st *C.MPI_Status, dt C.MPI_Datatype) Status {
+	s := Status{Source: int(st.MPI_SOURCE), Tag: int(st.MPI_TAG)}
+	if st.MPI_ERROR != C.MPI_SUCCESS {
+		s.Error = Error(st.MPI_ERROR, "Status")
+	}
+	if dt == C.MPI_DATATYPE_NULL {
+		s.Count = -1
+		return s
+	}
+	var n C.int
+	err := Error(C.MPI_Get_count(st, dt, &n), "Get_count")
+	if err != nil || n == C.MPI_UNDEFINED {
+		s.Count = -1
+	} else {
+		s.Count = int(n)
+	}
+	return s
+}
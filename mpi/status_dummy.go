@@ -0,0 +1,28 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !mpi
+
+package mpi
+
+// Status is the status of a completed receive (or probed message),
+// as returned by functions that report which message was received.
+type Status struct {
+
+	// Source is the rank of the proc that sent the message.
+	Source int
+
+	// Tag is the tag of the message.
+	Tag int
+
+	// Count is the number of values of the datatype of the receive
+	// operation in the message, or -1 if the message size is not
+	// an integer multiple of that datatype size, or for operations
+	// other than receives.
+	Count int
+
+	// Error is the error for this message, which is only set when
+	// completing multiple operations at once.
+	Error error
+}
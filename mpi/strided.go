@@ -0,0 +1,62 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+
+package mpi
+
+/*
+#cgo pkg-config: ompi
+#include "mpi.h"
+
+extern MPI_Datatype FLOAT32;
+*/
+import "C"
+
+import "unsafe"
+
+// AllReduceStridedF32 reduces count values across procs to all procs from orig
+// into dest using given operation, for the strided elements at
+// offset + i * stride, for i < count (e.g., one channel of an interleaved layout),
+// which are stored into the same elements of dest, leaving the others unchanged.
+// This uses an MPI vector datatype to describe the strided elements,
+// avoiding the need to copy them into a contiguous buffer.
+// IMPORTANT: orig and dest must be different slices
+// To do an in-place operation, set orig to nil
+func (cm *Comm) AllReduceStridedF32(op Op, dest, orig []float32, count, stride, offset int) error {
+	cm.countMetric("AllReduceStrided", max(count, 0)*int(unsafe.Sizeof(float32(0))))
+	defer cm.enterCollective()()
+	if count == 0 {
+		return nil
+	}
+	last := offset + (count-1)*stride
+	if count < 0 || stride < 1 || offset < 0 || last >= len(dest) || (orig != nil && last >= len(orig)) {
+		return errorf("mpi.AllReduceStridedF32: count %d, stride %d, offset %d out of range for len(dest) %d, len(orig) %d", count, stride, offset, len(dest), len(orig))
+	}
+	// user-defined Ops assume contiguous values, not the vector datatype
+	if err := checkUserOp(op, "AllReduceStridedF32"); err != nil {
+		return err
+	}
+	if err := cm.checkOp(op, "AllReduceStridedF32"); err != nil {
+		return err
+	}
+	var vt C.MPI_Datatype
+	err := Error(C.MPI_Type_vector(C.int(count), 1, C.int(stride), C.FLOAT32, &vt), "Type_vector")
+	if err != nil {
+		return err
+	}
+	defer C.MPI_Type_free(&vt)
+	err = Error(C.MPI_Type_commit(&vt), "Type_commit")
+	if err != nil {
+		return err
+	}
+	var sendbuf unsafe.Pointer
+	if orig != nil {
+		sendbuf = unsafe.Pointer(&orig[offset])
+	} else {
+		sendbuf = C.MPI_IN_PLACE
+	}
+	recvbuf := unsafe.Pointer(&dest[offset])
+	return Error(C.MPI_Allreduce(sendbuf, recvbuf, 1, vt, op.ToC(), cm.comm), "AllReduceStridedF32")
+}
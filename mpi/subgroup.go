@@ -0,0 +1,50 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import "fmt"
+
+// subgroup returns the sub-communicator for given ranks within this
+// communicator, creating it if it has not already been created,
+// which must be done on all procs in this communicator.
+// Returns nil for procs that are not in ranks.
+func (cm *Comm) subgroup(ranks []int) (*Comm, error) {
+	if len(ranks) == 0 {
+		return nil, errorf("mpi.subgroup: no ranks specified")
+	}
+	key := fmt.Sprint(ranks)
+	if sc, has := cm.subs[key]; has {
+		return sc, nil
+	}
+	sc, err := cm.newSubComm(ranks)
+	if err != nil {
+		return nil, err
+	}
+	if cm.subs == nil {
+		cm.subs = make(map[string]*Comm)
+	}
+	cm.subs[key] = sc
+	return sc, nil
+}
+
+// SubgroupAllReduceF32 reduces the values in buf across only the procs with given
+// ranks in this communicator, using given operation, and then broadcasts the
+// result to all procs in this communicator, so that buf has the result on all procs.
+// The sub-communicator for the ranks is created the first time it is used,
+// and cached for subsequent calls.  Must be called on all procs in this
+// communicator, with the same ranks.
+func (cm *Comm) SubgroupAllReduceF32(ranks []int, op Op, buf []float32) error {
+	sc, err := cm.subgroup(ranks)
+	if err != nil || len(buf) == 0 {
+		return err
+	}
+	if sc != nil {
+		err = sc.AllReduceF32(op, buf, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return cm.BcastF32(ranks[0], buf)
+}
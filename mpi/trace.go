@@ -0,0 +1,62 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+// TraceCollectives turns on counting of the collective calls (Barrier,
+// Bcast, Reduce, AllReduce, Gather, Scatter, etc) made on each Comm,
+// so that AssertCollectiveSync can check that all procs have made
+// the same number of calls.  It must be set the same way on all procs.
+var TraceCollectives = false
+
+// CheckOps turns on checking that all procs pass the same Op to each
+// reduction (Reduce, AllReduce, etc), which MPI does not check, and which
+// otherwise silently produces plausible but wrong results.  Each reduction
+// first all-reduces the Op values, returning an error on all procs if they
+// differ.  This is for debugging, as it adds a small collective call to
+// each reduction.  It must be set the same way on all procs.
+var CheckOps = false
+
+// traceCollective counts a collective call if TraceCollectives is on.
+func (cm *Comm) traceCollective() {
+	if TraceCollectives {
+		cm.nCollectives++
+	}
+}
+
+// nopExit is the exit function returned by enterCollective
+// when there is nothing to do at the end of a collective.
+func nopExit() {}
+
+// NumCollectives returns the number of collective calls made on this Comm
+// while TraceCollectives is on (always 0 when not built with mpi).
+func (cm *Comm) NumCollectives() int {
+	return cm.nCollectives
+}
+
+// AssertCollectiveSync checks that all procs in this Comm have made the same
+// number of collective calls while TraceCollectives is on, returning an error
+// on all procs, reporting the range of counts, if they differ.
+// A proc making one more or fewer collective call than the others, due to
+// divergent control flow, is a common cause of deadlocks and corrupted results:
+// calling this periodically identifies where such divergence occurs.
+// This is itself a collective call that must be made on all procs,
+// and it is not included in the count.
+func (cm *Comm) AssertCollectiveSync() error {
+	if cm.Size() == 1 {
+		return nil
+	}
+	n := cm.nCollectives
+	cnt := []int{n, -n}
+	ext := []int{0, 0}
+	err := cm.AllReduceInt(OpMax, ext, cnt)
+	cm.nCollectives = n
+	if err != nil {
+		return err
+	}
+	if ext[0] != -ext[1] {
+		return errorf("mpi.AssertCollectiveSync: procs have made different numbers of collective calls: min %d != max %d (this proc: %d: %d)", -ext[1], ext[0], cm.Rank(), n)
+	}
+	return nil
+}
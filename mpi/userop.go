@@ -0,0 +1,74 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import "sync"
+
+// maxUserOps is the maximum number of user-defined Ops created by NewOp
+// that can exist at the same time.
+const maxUserOps = 16
+
+// opUser is the Op value for the first user-defined Op slot,
+// well above the standard Op values.
+const opUser Op = 1000
+
+var (
+	userOpMu    sync.Mutex
+	userOpFuncs [maxUserOps]func(in, inout []float32)
+)
+
+// addUserOp stores given function in a free user-defined Op slot,
+// returning the slot index.
+func addUserOp(fn func(in, inout []float32)) (int, error) {
+	if fn == nil {
+		return -1, errorf("mpi.NewOp: function is nil")
+	}
+	userOpMu.Lock()
+	defer userOpMu.Unlock()
+	for i, f := range userOpFuncs {
+		if f == nil {
+			userOpFuncs[i] = fn
+			return i, nil
+		}
+	}
+	return -1, errorf("mpi.NewOp: maximum number of user-defined Ops: %d already exist; use FreeOp to free unused ones", maxUserOps)
+}
+
+// userOpSlot returns the user-defined Op slot index for given Op,
+// and false if it is not a current user-defined Op.
+func userOpSlot(op Op) (int, bool) {
+	i := int(op - opUser)
+	if i < 0 || i >= maxUserOps {
+		return -1, false
+	}
+	userOpMu.Lock()
+	defer userOpMu.Unlock()
+	return i, userOpFuncs[i] != nil
+}
+
+// userOpFunc returns the function for given user-defined Op slot.
+func userOpFunc(slot int) func(in, inout []float32) {
+	userOpMu.Lock()
+	defer userOpMu.Unlock()
+	return userOpFuncs[slot]
+}
+
+// removeUserOp frees given user-defined Op slot.
+func removeUserOp(slot int) {
+	userOpMu.Lock()
+	userOpFuncs[slot] = nil
+	userOpMu.Unlock()
+}
+
+// checkUserOp returns an error if op is a user-defined Op from NewOp,
+// which is only valid for float32 methods on host memory, for use in
+// the other reduction methods, which would otherwise silently apply
+// the float32 function to values of a different type.
+func checkUserOp(op Op, name string) error {
+	if op < opUser {
+		return nil
+	}
+	return errorf("mpi.%s: user-defined Op %d from NewOp is only valid for float32 methods", name, op)
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !mpi
+
+package mpi
+
+// NewOp returns a new user-defined Op that combines values using given
+// function, which must combine the in values into the inout values in place,
+// element-wise, e.g., for a saturating sum with clamping.  commutative
+// indicates if the order of combining can be changed, which allows
+// more efficient reductions.  The Op is only valid for float32 methods,
+// e.g., AllReduceF32, and the other reduction methods return an error for it.
+// It must be created in the same order on all procs, after Init.
+// Use FreeOp when it is no longer needed.
+func NewOp(fn func(in, inout []float32), commutative bool) (Op, error) {
+	slot, err := addUserOp(fn)
+	if err != nil {
+		return OpSum, err
+	}
+	return opUser + Op(slot), nil
+}
+
+// FreeOp frees a user-defined Op created by NewOp.
+func FreeOp(op Op) error {
+	slot, ok := userOpSlot(op)
+	if !ok {
+		return errorf("mpi.FreeOp: Op %d is not a user-defined Op", op)
+	}
+	removeUserOp(slot)
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+
+package mpi
+
+// this file only contains the exported callback for user-defined Ops,
+// because cgo does not allow C definitions in the preamble of a file
+// with exported functions, and the trampolines are in userop_mpi.go.
+
+import "C"
+
+import "unsafe"
+
+//export goUserOpF32
+func goUserOpF32(slot C.int, in, inout unsafe.Pointer, n *C.int) {
+	fn := userOpFunc(int(slot))
+	if fn == nil || *n <= 0 {
+		return
+	}
+	fn(unsafe.Slice((*float32)(in), int(*n)), unsafe.Slice((*float32)(inout), int(*n)))
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build mpi
+
+package mpi
+
+/*
+#cgo pkg-config: ompi
+#include "mpi.h"
+
+extern void goUserOpF32(int slot, void* in, void* inout, int* len);
+
+// MPI_User_function does not identify the op being applied, so each
+// user-defined Op slot has its own trampoline into Go.
+#define USER_OP(i) static void userOpF32_##i(void *in, void *inout, int *len, MPI_Datatype *dt) { goUserOpF32(i, in, inout, len); }
+USER_OP(0)
+USER_OP(1)
+USER_OP(2)
+USER_OP(3)
+USER_OP(4)
+USER_OP(5)
+USER_OP(6)
+USER_OP(7)
+USER_OP(8)
+USER_OP(9)
+USER_OP(10)
+USER_OP(11)
+USER_OP(12)
+USER_OP(13)
+USER_OP(14)
+USER_OP(15)
+
+static MPI_User_function* userOpTrampolines[] = {userOpF32_0, userOpF32_1, userOpF32_2, userOpF32_3, userOpF32_4, userOpF32_5, userOpF32_6, userOpF32_7, userOpF32_8, userOpF32_9, userOpF32_10, userOpF32_11, userOpF32_12, userOpF32_13, userOpF32_14, userOpF32_15};
+
+static int createUserOp(int slot, int commute, MPI_Op *op) {
+	return MPI_Op_create(userOpTrampolines[slot], commute, op);
+}
+*/
+import "C"
+
+// userOpsC are the MPI ops for the user-defined Op slots.
+var userOpsC [maxUserOps]C.MPI_Op
+
+// NewOp returns a new user-defined Op that combines values using given
+// function, which must combine the in values into the inout values in place,
+// element-wise, e.g., for a saturating sum with clamping.  commutative
+// indicates if the order of combining can be changed, which allows
+// more efficient reductions.  The Op is only valid for float32 methods,
+// e.g., AllReduceF32, and the other reduction methods return an error for it.
+// It must be created in the same order on all procs, after Init.
+// Use FreeOp when it is no longer needed.
+func NewOp(fn func(in, inout []float32), commutative bool) (Op, error) {
+	slot, err := addUserOp(fn)
+	if err != nil {
+		return OpSum, err
+	}
+	cm := C.int(0)
+	if commutative {
+		cm = 1
+	}
+	err = Error(C.createUserOp(C.int(slot), cm, &userOpsC[slot]), "Op_create")
+	if err != nil {
+		removeUserOp(slot)
+		return OpSum, err
+	}
+	return opUser + Op(slot), nil
+}
+
+// FreeOp frees a user-defined Op created by NewOp.
+func FreeOp(op Op) error {
+	slot, ok := userOpSlot(op)
+	if !ok {
+		return errorf("mpi.FreeOp: Op %d is not a user-defined Op", op)
+	}
+	err := Error(C.MPI_Op_free(&userOpsC[slot]), "Op_free")
+	removeUserOp(slot)
+	return err
+}
+
+// userOpC returns the MPI op for given user-defined Op,
+// or MPI_OP_NULL if it is not a current one.
+func userOpC(op Op) C.MPI_Op {
+	slot, ok := userOpSlot(op)
+	if !ok {
+		return C.MPI_OP_NULL
+	}
+	return userOpsC[slot]
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUserOpReduceLocal(t *testing.T) {
+	// saturating sum, clamped at 1
+	op, err := NewOp(func(in, inout []float32) {
+		for i, v := range in {
+			inout[i] = min(inout[i]+v, 1)
+		}
+	}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer FreeOp(op)
+
+	dest := []float32{0.5, 0.25}
+	if err := ReduceLocalF32(op, dest, []float32{0.75, 0.25}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []float32{1, 0.5}; !slices.Equal(dest, want) {
+		t.Errorf("ReduceLocalF32: got %v, want %v", dest, want)
+	}
+	if err := ReduceLocalF64(op, []float64{0.5}, []float64{0.75}); err == nil {
+		t.Errorf("ReduceLocalF64: expected error for user-defined Op")
+	}
+}
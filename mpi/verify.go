@@ -0,0 +1,85 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import (
+	"log"
+	"math"
+)
+
+// VerifyReduce turns on checking of the result of each Reduce call on the
+// toProc proc against an independent AllReduce of the same values, logging
+// any discrepancies and returning an error, e.g., to catch Op or datatype
+// mismatches.  This is purely diagnostic: it doubles the cost of Reduce,
+// and has no cost when off.  It must be set the same way on all procs.
+var VerifyReduce = false
+
+// VerifyReduceTol is the relative tolerance used by VerifyReduce for
+// floating point values, which can differ slightly between Reduce and
+// AllReduce due to a different order of reduction.
+var VerifyReduceTol = 1.0e-6
+
+// verifyReduceMaxLog is the maximum number of discrepancies logged
+// by verifyReduce for a single call.
+const verifyReduceMaxLog = 10
+
+// verifyReduce checks the dest values from a Reduce against the ver values
+// from an AllReduce, for VerifyReduce.
+func verifyReduce[T Numeric](dest, ver []T, name string) error {
+	if len(dest) < len(ver) {
+		return errorf("mpi.%s: VerifyReduce: dest has %d values, less than the %d reduced", name, len(dest), len(ver))
+	}
+	nbad := 0
+	first := -1
+	for i, v := range ver {
+		if reduceMatch(dest[i], v) {
+			continue
+		}
+		if nbad == 0 {
+			first = i
+		}
+		if nbad < verifyReduceMaxLog {
+			log.Printf("mpi.%s: VerifyReduce: index %d: Reduce: %v != AllReduce: %v\n", name, i, dest[i], v)
+		}
+		nbad++
+	}
+	if nbad > 0 {
+		return errorf("mpi.%s: VerifyReduce: %d of %d values differ from AllReduce, first at index %d", name, nbad, len(ver), first)
+	}
+	return nil
+}
+
+// reduceMatch returns true if given values match for verifyReduce,
+// using VerifyReduceTol for floating point values.
+func reduceMatch[T Numeric](a, b T) bool {
+	if a == b {
+		return true
+	}
+	switch av := any(a).(type) {
+	case float32:
+		return floatsClose(float64(av), float64(any(b).(float32)))
+	case float64:
+		return floatsClose(av, any(b).(float64))
+	case complex64:
+		bv := any(b).(complex64)
+		return floatsClose(float64(real(av)), float64(real(bv))) && floatsClose(float64(imag(av)), float64(imag(bv)))
+	case complex128:
+		bv := any(b).(complex128)
+		return floatsClose(real(av), real(bv)) && floatsClose(imag(av), imag(bv))
+	}
+	return false
+}
+
+// floatsClose returns true if a and b are within VerifyReduceTol
+// of each other, relative to their magnitude, or are both NaN.
+func floatsClose(a, b float64) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.IsNaN(a) && math.IsNaN(b)
+	}
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) <= VerifyReduceTol*max(math.Abs(a), math.Abs(b))
+}
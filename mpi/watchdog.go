@@ -0,0 +1,164 @@
+// Copyright (c) 2024, The Emergent Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpi
+
+import (
+	"log"
+	"runtime"
+	"time"
+)
+
+// WatchdogTimeout is how long StartWatchdog waits for each liveness check
+// to complete before concluding that some proc is hung.
+var WatchdogTimeout = 5 * time.Minute
+
+// WatchdogAbort determines whether StartWatchdog aborts all procs,
+// with exit code WatchdogExitCode, after a liveness check has timed out.
+var WatchdogAbort = false
+
+// WatchdogExitCode is the distinctive exit code used to abort
+// when a liveness check times out and WatchdogAbort is set,
+// so that hung jobs can be identified from the exit status.
+var WatchdogExitCode = 86
+
+// StartWatchdog launches a background goroutine that checks every interval
+// that all procs are still alive, using a non-blocking IBarrier on a private
+// duplicate of the communicator, which does not interfere with other
+// communication.  If a check has not completed within WatchdogTimeout,
+// indicating that some proc is hung, a stack dump of all goroutines on this
+// proc is logged, and if WatchdogAbort is set, all procs are aborted with
+// WatchdogExitCode.  The stack dumps show where each proc is stuck.
+// It must be called by all procs at the same point, and requires MPI to
+// have been initialized with InitThreadSafe, because MPI is then called from
+// multiple goroutines.  The returned stop function stops the watchdog,
+// waiting for the goroutine to exit and completing its last check,
+// and must likewise be called by all procs at the same point, before Finalize.
+func StartWatchdog(cm *Comm, interval time.Duration) (stop func(), err error) {
+	stop = func() {}
+	if cm.Size() == 1 {
+		return
+	}
+	if !threadMultiple {
+		err = errorf("mpi.StartWatchdog: MPI must be initialized with InitThreadSafe")
+		return
+	}
+	wc, err := cm.dup()
+	if err != nil {
+		return
+	}
+	w := &watchdogState{comm: wc, interval: interval, done: make(chan struct{}), exited: make(chan struct{})}
+	go w.run()
+	return w.stop, nil
+}
+
+// watchdogState holds the state for StartWatchdog.
+type watchdogState struct {
+	// comm is the private duplicate of the communicator for the checks.
+	comm *Comm
+
+	// interval is the time between checks.
+	interval time.Duration
+
+	// done is closed by stop to signal the goroutine to exit.
+	done chan struct{}
+
+	// exited is closed by the goroutine when it exits, after which
+	// the fields below can be accessed by stop.
+	exited chan struct{}
+
+	// req is the check that has not yet completed, if any.
+	req *Request
+
+	// started is the number of checks started.
+	started int
+}
+
+// run runs the liveness checks until done is closed.
+func (w *watchdogState) run() {
+	defer close(w.exited)
+	poll := min(w.interval, 100*time.Millisecond)
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-time.After(w.interval):
+		}
+		req, err := w.comm.IBarrier()
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		w.req = req
+		w.started++
+		start := time.Now()
+		hung := false
+		for {
+			ok, err := req.Test()
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			if ok {
+				w.req = nil
+				break
+			}
+			if !hung && time.Since(start) > WatchdogTimeout {
+				hung = true
+				watchdogHung(w.comm, time.Since(start))
+			}
+			select {
+			case <-w.done:
+				return
+			case <-time.After(poll):
+			}
+		}
+	}
+}
+
+// stop signals the goroutine to exit and waits for it, then completes the
+// outstanding check, and frees the communicator.  A check can only start
+// after the previous one has completed on all procs, so the number started
+// differs by at most one across procs: those that are one behind start
+// a last check to match the outstanding one on the others.
+// A nonblocking collective cannot be cancelled, so this is the only way
+// to complete it.
+func (w *watchdogState) stop() {
+	close(w.done)
+	<-w.exited
+	defer func() {
+		if err := w.comm.Free(); err != nil {
+			log.Println(err)
+		}
+	}()
+	nmax := []int{0}
+	if err := w.comm.AllReduceInt(OpMax, nmax, []int{w.started}); err != nil {
+		log.Println(err)
+		return
+	}
+	if w.started < nmax[0] {
+		req, err := w.comm.IBarrier()
+		if err != nil {
+			log.Println(err)
+			return
+		}
+		w.req = req
+	}
+	if w.req != nil {
+		if err := w.req.Wait(); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// watchdogHung logs a stack dump of all goroutines, and aborts
+// if WatchdogAbort is set.
+func watchdogHung(wc *Comm, wait time.Duration) {
+	buf := make([]byte, 1<<20)
+	buf = buf[:runtime.Stack(buf, true)]
+	log.Printf("mpi.StartWatchdog: liveness check not completed after %v on proc %d: some proc may be hung: goroutines:\n%s\n", wait, wc.Rank(), buf)
+	if WatchdogAbort {
+		wc.abort(WatchdogExitCode)
+	}
+}